@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+func lint(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("lint", "contracts.json...", stderr)
+	descriptorSets := fs.String("descriptor_set", "", "comma-separated proto descriptor set files")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *descriptorSets == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	files, err := loadDescriptorSets(splitList(*descriptorSets))
+	if err != nil {
+		fmt.Fprintln(stderr, "grpc-contracts:", err)
+		return 2
+	}
+
+	problems := 0
+	for _, name := range fs.Args() {
+		parsed, err := readContracts([]string{name})
+		if err != nil {
+			fmt.Fprintln(stdout, err)
+			problems++
+			continue
+		}
+		for _, err := range parsed[0].Lint(files) {
+			fmt.Fprintf(stdout, "%s: %v\n", name, err)
+			problems++
+		}
+	}
+	if problems > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/spec"
+)
+
+func list(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("list", "contracts.json...", stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	files, err := readContracts(fs.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, "grpc-contracts:", err)
+		return 2
+	}
+	for _, f := range files {
+		for _, s := range f.Services {
+			printService(stdout, s)
+		}
+	}
+	return 0
+}
+
+func printService(w io.Writer, s *spec.Service) {
+	fmt.Fprintln(w, s.Name)
+	if s.RequireDeadlinePropagation {
+		fmt.Fprintln(w, "  requires deadline propagation")
+	}
+	printMetadata(w, "  ", "incoming metadata", s.IncomingMetadata)
+	for _, dc := range s.DownstreamMetadata {
+		printMetadata(w, "  ", "metadata of calls to "+dc.Target, &dc.Metadata)
+	}
+	for _, m := range s.Methods {
+		fmt.Fprintf(w, "  %s\n", m.Name)
+		for _, c := range m.PreConditions {
+			fmt.Fprintf(w, "    precondition: %s\n", c)
+		}
+		for _, c := range m.PostConditions {
+			fmt.Fprintf(w, "    postcondition: %s\n", c)
+		}
+		if m.CallSequence != "" {
+			fmt.Fprintf(w, "    call sequence: %s\n", m.CallSequence)
+		}
+		if len(m.AllowedCodes) > 0 {
+			fmt.Fprintf(w, "    allowed codes: %s\n", strings.Join(m.AllowedCodes, ", "))
+		}
+		if m.MaxLatency != "" {
+			fmt.Fprintf(w, "    max latency: %s\n", m.MaxLatency)
+		}
+		for _, o := range m.LatencyObjectives {
+			fmt.Fprintf(w, "    latency objective: p%g < %s\n", o.Percentile, o.Threshold)
+		}
+		if m.Idempotency != nil {
+			key := m.Idempotency.KeyMetadata
+			if key == "" {
+				key = "request." + m.Idempotency.KeyField
+			}
+			fmt.Fprintf(w, "    idempotent by %s\n", key)
+		}
+		printMetadata(w, "    ", "incoming metadata", m.IncomingMetadata)
+		for _, dc := range m.DownstreamMetadata {
+			printMetadata(w, "    ", "metadata of calls to "+dc.Target, &dc.Metadata)
+		}
+	}
+}
+
+func printMetadata(w io.Writer, indent, title string, mc *spec.Metadata) {
+	if mc == nil {
+		return
+	}
+	if len(mc.Required) > 0 {
+		fmt.Fprintf(w, "%s%s: requires %s\n", indent, title, strings.Join(mc.Required, ", "))
+	}
+	if len(mc.Forbidden) > 0 {
+		fmt.Fprintf(w, "%s%s: forbids %s\n", indent, title, strings.Join(mc.Forbidden, ", "))
+	}
+	for key, pattern := range mc.Patterns {
+		fmt.Fprintf(w, "%s%s: %s matches %s\n", indent, title, key, pattern)
+	}
+}
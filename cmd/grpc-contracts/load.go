@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/spec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// splitList splits a comma-separated list of file names.
+func splitList(s string) []string {
+	var res []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// loadDescriptorSets reads descriptor sets into a registry of files.
+func loadDescriptorSets(names []string) (*protoregistry.Files, error) {
+	set := new(descriptorpb.FileDescriptorSet)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		s := new(descriptorpb.FileDescriptorSet)
+		if err := proto.Unmarshal(data, s); err != nil {
+			return nil, fmt.Errorf("parsing %s: %v", name, err)
+		}
+		for _, f := range s.File {
+			if !seen[f.GetName()] {
+				seen[f.GetName()] = true
+				set.File = append(set.File, f)
+			}
+		}
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("descriptor sets: %v", err)
+	}
+	return files, nil
+}
+
+// registerGlobally registers the files and their messages to the global
+// registries, so that recorded messages can be decoded. Files that are
+// already registered, e.g., the ones linked into the binary, are skipped.
+func registerGlobally(files *protoregistry.Files) error {
+	var err error
+	files.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+		if _, e := protoregistry.GlobalFiles.FindFileByPath(fd.Path()); e == nil {
+			return true
+		}
+		if err = protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+			return false
+		}
+		err = registerMessages(fd.Messages())
+		return err == nil
+	})
+	return err
+}
+
+func registerMessages(mds protoreflect.MessageDescriptors) error {
+	for i := 0; i < mds.Len(); i++ {
+		md := mds.Get(i)
+		if _, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err != nil {
+			if err := protoregistry.GlobalTypes.RegisterMessage(dynamicpb.NewMessageType(md)); err != nil {
+				return err
+			}
+		}
+		if err := registerMessages(md.Messages()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readContracts reads contract files.
+func readContracts(names []string) ([]*spec.File, error) {
+	var res []*spec.File
+	for _, name := range names {
+		f, err := spec.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
@@ -0,0 +1,65 @@
+// Command grpc-contracts lints and inspects contract files and replays
+// recorded traffic against them.
+//
+// Usage:
+//
+//	grpc-contracts lint -descriptor_set set.pb contracts.json...
+//	grpc-contracts list contracts.json...
+//	grpc-contracts replay -descriptor_set set.pb -contracts contracts.json [-format jsonl|delimited] [-output table|json] traffic
+//
+// Contract files are described in package spec, and traffic files in package
+// traffic. Descriptor sets can be generated with
+// `protoc --include_imports --descriptor_set_out=set.pb`. Multiple files can be
+// passed to -descriptor_set and -contracts, separated by commas.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: grpc-contracts <command> [flags] [args]
+
+commands:
+  lint     check contract files against proto descriptor sets
+  list     show the contracts of contract files per service
+  replay   replay a recorded traffic file against contract files
+
+Run 'grpc-contracts <command> -h' for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs a command and returns the exit code: 0 on success, 1 if problems
+// or violations are found, and 2 on usage and input errors.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	commands := map[string]func([]string, io.Writer, io.Writer) int{
+		"lint":   lint,
+		"list":   list,
+		"replay": replay,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "grpc-contracts: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+	return cmd(args[1:], stdout, stderr)
+}
+
+func newFlagSet(name, args string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: grpc-contracts %s [flags] %s\n", name, args)
+		fs.PrintDefaults()
+	}
+	return fs
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/traffic"
+)
+
+const contractsFile = `{
+  "services": [{
+    "service": "contracts.test.Notes",
+    "incoming_metadata": {"required": ["x-request-id"]},
+    "methods": [{
+      "method": "GetNote",
+      "preconditions": ["request.note_id >= 0"],
+      "postconditions": ["code != \"OK\" || calls[\"Authenticate\"] > 0"],
+      "allowed_codes": ["NOT_FOUND"],
+      "max_latency": "100ms",
+      "latency_objectives": [{"percentile": 99, "threshold": "50ms"}],
+      "idempotency": {"key_field": "note_id"},
+      "downstream_metadata": [{"target": "contracts.test.Auth", "forbidden": ["cookie"]}]
+    }]
+  }]
+}`
+
+const invalidFile = `{
+  "services": [{
+    "service": "contracts.test.Notes",
+    "methods": [
+      {"method": "DeleteNote"},
+      {"method": "GetNote", "preconditions": ["request.title == \"\""]}
+    ]
+  }]
+}`
+
+// tempFiles writes the files to a temporary directory and returns their paths.
+func tempFiles(t *testing.T, files map[string][]byte) map[string]string {
+	dir := t.TempDir()
+	paths := make(map[string]string)
+	for name, data := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+// delimited converts testdata/traffic.jsonl to the delimited format.
+func delimited(t *testing.T) []byte {
+	files, err := loadDescriptorSets([]string{"testdata/notes.pb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registerGlobally(files); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open("testdata/traffic.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r := traffic.NewReader(f, traffic.JSONL)
+	var buf bytes.Buffer
+	rec := traffic.NewRecorder(&buf, traffic.Delimited)
+	for {
+		report, err := r.Next()
+		if err == io.EOF {
+			return buf.Bytes()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rec.Report(context.Background(), report); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	jsonl, err := os.ReadFile("testdata/traffic.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := tempFiles(t, map[string][]byte{
+		"contracts.json":  []byte(contractsFile),
+		"invalid.json":    []byte(invalidFile),
+		"malformed.json":  []byte(`{"services": {}}`),
+		"traffic.pb":      delimited(t),
+		"passing.jsonl":   jsonl[:bytes.LastIndexByte(jsonl[:len(jsonl)-1], '\n')+1],
+		"corrupted.jsonl": []byte("{\n"),
+	})
+	f["set.pb"] = "testdata/notes.pb"
+	f["traffic.jsonl"] = "testdata/traffic.jsonl"
+	authViolation := `code != "OK" || calls["Authenticate"] > 0`
+	table := "RECORD  METHOD                         VIOLATION                                  ERROR\n" +
+		"3       /contracts.test.Notes/GetNote  " + authViolation + "  condition is false\n" +
+		"\n1 of 3 requests violate the contracts\n"
+
+	tests := []struct {
+		name       string
+		args       []string
+		code       int
+		stdout     string
+		stderrHas  string
+		stdoutHas  []string
+		violations []violation
+	}{
+		{name: "no command", code: 2, stderrHas: "usage: grpc-contracts <command>"},
+		{name: "unknown command", args: []string{"check"}, code: 2, stderrHas: `unknown command "check"`},
+		{name: "lint", args: []string{"lint", "-descriptor_set", f["set.pb"], f["contracts.json"]}, code: 0},
+		{
+			name: "lint problems",
+			args: []string{"lint", "-descriptor_set", " ," + f["set.pb"] + "," + f["set.pb"], f["contracts.json"], f["invalid.json"], f["malformed.json"]},
+			code: 1,
+			stdoutHas: []string{
+				f["invalid.json"] + ": service contracts.test.Notes: method DeleteNote not found\n",
+				f["invalid.json"] + `: service contracts.test.Notes: method GetNote: precondition "request.title == \"\"": 1:8: undefined field 'title'` + "\n",
+				"spec: parsing " + f["malformed.json"] + ":",
+			},
+		},
+		{name: "lint without descriptor sets", args: []string{"lint", f["contracts.json"]}, code: 2, stderrHas: "usage: grpc-contracts lint"},
+		{name: "lint without files", args: []string{"lint", "-descriptor_set", f["set.pb"]}, code: 2, stderrHas: "usage: grpc-contracts lint"},
+		{name: "lint missing descriptor set", args: []string{"lint", "-descriptor_set", f["set.pb"] + ".missing", f["contracts.json"]}, code: 2, stderrHas: "no such file"},
+		{name: "lint invalid descriptor set", args: []string{"lint", "-descriptor_set", f["contracts.json"], f["contracts.json"]}, code: 2, stderrHas: "parsing " + f["contracts.json"]},
+		{name: "lint unknown flag", args: []string{"lint", "-descriptor_sets", f["set.pb"]}, code: 2, stderrHas: "flag provided but not defined"},
+		{
+			name: "list",
+			args: []string{"list", f["contracts.json"]},
+			code: 0,
+			stdout: `contracts.test.Notes
+  incoming metadata: requires x-request-id
+  GetNote
+    precondition: request.note_id >= 0
+    postcondition: ` + authViolation + `
+    allowed codes: NOT_FOUND
+    max latency: 100ms
+    latency objective: p99 < 50ms
+    idempotent by request.note_id
+    metadata of calls to contracts.test.Auth: forbids cookie
+`,
+		},
+		{name: "list without files", args: []string{"list"}, code: 2, stderrHas: "usage: grpc-contracts list"},
+		{name: "list malformed", args: []string{"list", f["malformed.json"]}, code: 2, stderrHas: "spec: parsing " + f["malformed.json"]},
+		{
+			name:   "replay",
+			args:   []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], f["traffic.jsonl"]},
+			code:   1,
+			stdout: table,
+		},
+		{
+			name:   "replay delimited",
+			args:   []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], "-format", "delimited", f["traffic.pb"]},
+			code:   1,
+			stdout: table,
+		},
+		{
+			name: "replay json",
+			args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], "-output", "json", f["traffic.jsonl"]},
+			code: 1,
+			violations: []violation{{
+				Record:  3,
+				TraceID: "trace-2",
+				Method:  "/contracts.test.Notes/GetNote",
+				Name:    authViolation,
+				Error:   "condition is false",
+			}},
+		},
+		{
+			name:       "replay json passing",
+			args:       []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], "-output", "json", f["passing.jsonl"]},
+			code:       0,
+			violations: []violation{},
+		},
+		{name: "replay unknown format", args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], "-format", "csv", f["traffic.jsonl"]}, code: 2, stderrHas: "usage: grpc-contracts replay"},
+		{name: "replay unknown output", args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], "-output", "xml", f["traffic.jsonl"]}, code: 2, stderrHas: "usage: grpc-contracts replay"},
+		{name: "replay without contracts", args: []string{"replay", "-descriptor_set", f["set.pb"], f["traffic.jsonl"]}, code: 2, stderrHas: "usage: grpc-contracts replay"},
+		{name: "replay invalid contracts", args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["invalid.json"], f["traffic.jsonl"]}, code: 2, stderrHas: "method DeleteNote not found"},
+		{name: "replay missing traffic", args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], f["traffic.jsonl"] + ".missing"}, code: 2, stderrHas: "no such file"},
+		{name: "replay corrupted traffic", args: []string{"replay", "-descriptor_set", f["set.pb"], "-contracts", f["contracts.json"], f["corrupted.jsonl"]}, code: 2, stderrHas: "traffic: record 1:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != tt.code {
+				t.Errorf("exit code %d, want %d\nstdout:\n%s\nstderr:\n%s", code, tt.code, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.stderrHas) {
+				t.Errorf("stderr:\n%s\nwant it to contain %q", stderr.String(), tt.stderrHas)
+			}
+			switch {
+			case tt.violations != nil:
+				var got []violation
+				if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+					t.Fatalf("invalid JSON output %s: %v", stdout.String(), err)
+				}
+				if len(got) != len(tt.violations) || (len(got) > 0 && got[0] != tt.violations[0]) {
+					t.Errorf("violations %+v, want %+v", got, tt.violations)
+				}
+			case tt.stdoutHas != nil:
+				for _, s := range tt.stdoutHas {
+					if !strings.Contains(stdout.String(), s) {
+						t.Errorf("stdout:\n%s\nwant it to contain %q", stdout.String(), s)
+					}
+				}
+			default:
+				if stdout.String() != tt.stdout {
+					t.Errorf("stdout:\n%s\nwant:\n%s", stdout.String(), tt.stdout)
+				}
+			}
+		})
+	}
+}
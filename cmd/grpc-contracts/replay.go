@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/traffic"
+)
+
+// violation is a violation in the JSON output of replay.
+type violation struct {
+	Record  int    `json:"record"`
+	TraceID string `json:"trace_id"`
+	Method  string `json:"method"`
+	Call    string `json:"call,omitempty"`
+	Name    string `json:"name"`
+	Error   string `json:"error"`
+}
+
+func replay(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("replay", "traffic", stderr)
+	descriptorSets := fs.String("descriptor_set", "", "comma-separated proto descriptor set files")
+	contractFiles := fs.String("contracts", "", "comma-separated contract files")
+	format := fs.String("format", "jsonl", "format of the traffic file: jsonl or delimited")
+	output := fs.String("output", "table", "output format: table or json")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	formats := map[string]traffic.Format{"jsonl": traffic.JSONL, "delimited": traffic.Delimited}
+	trafficFormat, ok := formats[*format]
+	if *descriptorSets == "" || *contractFiles == "" || fs.NArg() != 1 || !ok || (*output != "table" && *output != "json") {
+		fs.Usage()
+		return 2
+	}
+
+	sc, err := loadServerContract(splitList(*descriptorSets), splitList(*contractFiles))
+	if err != nil {
+		fmt.Fprintln(stderr, "grpc-contracts:", err)
+		return 2
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintln(stderr, "grpc-contracts:", err)
+		return 2
+	}
+	defer f.Close()
+	results, err := traffic.Replay(traffic.NewReader(f, trafficFormat), sc)
+	if err != nil {
+		fmt.Fprintln(stderr, "grpc-contracts:", err)
+		return 2
+	}
+
+	violations := []violation{}
+	failed := 0
+	for i, res := range results {
+		if len(res.Violations) > 0 {
+			failed++
+		}
+		for _, v := range res.Violations {
+			err := v.Err
+			var ce *contracts.ConditionError
+			if errors.As(err, &ce) && ce.Name == v.Name {
+				err = ce.Err
+			}
+			violations = append(violations, violation{
+				Record:  i + 1,
+				TraceID: res.Report.TraceID,
+				Method:  v.FullMethod,
+				Call:    v.Call,
+				Name:    v.Name,
+				Error:   err.Error(),
+			})
+		}
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		enc.Encode(violations)
+	} else {
+		tw := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "RECORD\tMETHOD\tVIOLATION\tERROR")
+		for _, v := range violations {
+			method := v.Method
+			if v.Call != "" {
+				method += " -> " + v.Call
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", v.Record, method, v.Name, v.Error)
+		}
+		tw.Flush()
+		fmt.Fprintf(stdout, "\n%d of %d requests violate the contracts\n", failed, len(results))
+	}
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// loadServerContract builds a server contract from contract files. The
+// messages of the descriptor sets are registered globally, so that the
+// recorded requests and responses can be decoded.
+func loadServerContract(descriptorSets, contractFiles []string) (*contracts.ServerContract, error) {
+	files, err := loadDescriptorSets(descriptorSets)
+	if err != nil {
+		return nil, err
+	}
+	if err := registerGlobally(files); err != nil {
+		return nil, err
+	}
+	specs, err := readContracts(contractFiles)
+	if err != nil {
+		return nil, err
+	}
+	sc := contracts.NewServerContract(func(...interface{}) {})
+	for _, s := range specs {
+		svcs, err := s.Compile(nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range svcs {
+			if err := sc.RegisterServiceContract(svc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return sc, nil
+}
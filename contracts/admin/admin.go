@@ -0,0 +1,189 @@
+// Package admin implements the ContractsAdmin gRPC service, which inspects
+// and controls the contracts of a ServerContract at runtime: it lists the
+// registered contracts with the pass and fail counters of their conditions,
+// shows the recent violations, enables or disables conditions and services,
+// and changes the violation policy.
+//
+//	s := grpc.NewServer(grpc.UnaryInterceptor(serverContract.UnaryServerInterceptor()))
+//	adminServer := admin.NewServer(serverContract)
+//	defer adminServer.Close()
+//	adminpb.RegisterContractsAdminServer(s, adminServer)
+//
+// The service gives control over the contracts of the server to its callers,
+// so it should only be exposed to operators.
+package admin
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	pb "github.com/shayanh/grpc-go-contracts/contracts/admin/adminpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MaxRecentViolations is the number of recent violations kept by the server.
+const MaxRecentViolations = 100
+
+var policies = map[contracts.ViolationPolicy]pb.ViolationPolicy{
+	contracts.LogViolations:    pb.ViolationPolicy_VIOLATION_POLICY_LOG,
+	contracts.RejectViolations: pb.ViolationPolicy_VIOLATION_POLICY_REJECT,
+	contracts.IgnoreViolations: pb.ViolationPolicy_VIOLATION_POLICY_IGNORE,
+}
+
+// Server implements the ContractsAdmin gRPC service for a server contract.
+type Server struct {
+	pb.UnimplementedContractsAdminServer
+
+	sc             *contracts.ServerContract
+	removeReporter func()
+
+	mu         sync.Mutex
+	violations []*pb.Violation // ring buffer
+	next       int
+}
+
+// NewServer returns an implementation of the ContractsAdmin gRPC service for
+// the given server contract. Register it using
+// adminpb.RegisterContractsAdminServer. The server adds a reporter to the
+// server contract to keep the recent violations, so the violations detected
+// before it is created are not listed. Call Close to remove the reporter
+// when the server is no longer used.
+func NewServer(sc *contracts.ServerContract) *Server {
+	s := &Server{sc: sc}
+	s.removeReporter = sc.AddReporter(contracts.ReporterFunc(s.report))
+	return s
+}
+
+// Close removes the reporter of s from the server contract. The violations
+// detected afterwards are not listed by s.
+func (s *Server) Close() {
+	s.removeReporter()
+}
+
+func (s *Server) report(v *contracts.Violation) {
+	pv := &pb.Violation{
+		Name:       v.Name,
+		FullMethod: v.FullMethod,
+		Call:       v.Call,
+		Error:      v.Err.Error(),
+		Time:       timestamppb.Now(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.violations) < MaxRecentViolations {
+		s.violations = append(s.violations, pv)
+	} else {
+		s.violations[s.next] = pv
+	}
+	s.next = (s.next + 1) % MaxRecentViolations
+}
+
+func (s *Server) ListContracts(ctx context.Context, in *pb.ListContractsRequest) (*pb.ListContractsResponse, error) {
+	services := make(map[string]*pb.Service)
+	methods := make(map[string]*pb.Method)
+	addService := func(name string) *pb.Service {
+		svc, ok := services[name]
+		if !ok {
+			svc = &pb.Service{Name: name, Enabled: s.sc.ServiceEnabled(name)}
+			services[name] = svc
+		}
+		return svc
+	}
+	addMethod := func(service, fullMethod string) *pb.Method {
+		m, ok := methods[fullMethod]
+		if !ok {
+			m = &pb.Method{FullMethod: fullMethod}
+			methods[fullMethod] = m
+			svc := addService(service)
+			svc.Methods = append(svc.Methods, m)
+		}
+		return m
+	}
+
+	for _, svcContract := range s.sc.ServiceContracts() {
+		addService(svcContract.ServiceName)
+		for _, rpcContract := range svcContract.RPCContracts {
+			addMethod(svcContract.ServiceName, "/"+svcContract.ServiceName+"/"+rpcContract.MethodName)
+		}
+	}
+	for _, c := range s.sc.Conditions() {
+		if c.FullMethod == "" {
+			continue
+		}
+		m := addMethod(c.Service, c.FullMethod)
+		m.Conditions = append(m.Conditions, &pb.Condition{
+			Name:    c.Name,
+			Enabled: c.Enabled,
+			Passed:  c.Passed,
+			Failed:  c.Failed,
+		})
+	}
+
+	resp := new(pb.ListContractsResponse)
+	for _, svc := range services {
+		sort.Slice(svc.Methods, func(i, j int) bool {
+			return svc.Methods[i].FullMethod < svc.Methods[j].FullMethod
+		})
+		resp.Services = append(resp.Services, svc)
+	}
+	sort.Slice(resp.Services, func(i, j int) bool {
+		return resp.Services[i].Name < resp.Services[j].Name
+	})
+	return resp, nil
+}
+
+func (s *Server) ListViolations(ctx context.Context, in *pb.ListViolationsRequest) (*pb.ListViolationsResponse, error) {
+	if in.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "negative limit")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := new(pb.ListViolationsResponse)
+	for i := 1; i <= len(s.violations); i++ {
+		v := s.violations[(s.next-i+len(s.violations))%len(s.violations)]
+		if in.FullMethod != "" && v.FullMethod != in.FullMethod {
+			continue
+		}
+		if in.Limit > 0 && len(resp.Violations) == int(in.Limit) {
+			break
+		}
+		resp.Violations = append(resp.Violations, v)
+	}
+	return resp, nil
+}
+
+func (s *Server) SetServiceEnabled(ctx context.Context, in *pb.SetServiceEnabledRequest) (*pb.SetServiceEnabledResponse, error) {
+	if in.Service == "" {
+		return nil, status.Error(codes.InvalidArgument, "service is required")
+	}
+	s.sc.SetServiceEnabled(in.Service, in.Enabled)
+	return &pb.SetServiceEnabledResponse{}, nil
+}
+
+func (s *Server) SetConditionEnabled(ctx context.Context, in *pb.SetConditionEnabledRequest) (*pb.SetConditionEnabledResponse, error) {
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "condition name is required")
+	}
+	s.sc.SetConditionEnabled(in.FullMethod, in.Name, in.Enabled)
+	return &pb.SetConditionEnabledResponse{}, nil
+}
+
+func (s *Server) GetViolationPolicy(ctx context.Context, in *pb.GetViolationPolicyRequest) (*pb.GetViolationPolicyResponse, error) {
+	return &pb.GetViolationPolicyResponse{Policy: policies[s.sc.ViolationPolicy()]}, nil
+}
+
+func (s *Server) SetViolationPolicy(ctx context.Context, in *pb.SetViolationPolicyRequest) (*pb.SetViolationPolicyResponse, error) {
+	for p, pp := range policies {
+		if pp == in.Policy {
+			s.sc.SetViolationPolicy(p)
+			return &pb.SetViolationPolicyResponse{}, nil
+		}
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "invalid violation policy %v", in.Policy)
+}
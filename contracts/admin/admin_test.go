@@ -0,0 +1,312 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	pb "github.com/shayanh/grpc-go-contracts/contracts/admin/adminpb"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const getMethod = "/test.Front/Get"
+
+// serve serves test.Front, whose Get method must be called with a non-empty
+// value, and the admin service of its server contract. It returns the
+// server contract and connections to test.Front and the admin service.
+func serve(t *testing.T) (*contracts.ServerContract, grpc.ClientConnInterface, pb.ContractsAdminClient) {
+	sc := contracts.NewServerContract(t.Log)
+	err := sc.RegisterServiceContract(&contracts.ServiceContract{
+		ServiceName: "test.Front",
+		RPCContracts: []*contracts.UnaryRPCContract{{
+			MethodName: "Get",
+			PreConditions: []contracts.Condition{contracts.Named("nonempty", func(req *wrapperspb.StringValue) error {
+				if req.GetValue() == "" {
+					return errors.New("empty request")
+				}
+				return nil
+			})},
+			AllowedCodes: []codes.Code{codes.NotFound},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.RegisterServiceContract(&contracts.ServiceContract{ServiceName: "test.Back"}); err != nil {
+		t.Fatal(err)
+	}
+	frontCC := testservice.ServeMethods(t, "test.Front", map[string]testservice.Handler{"Get": testservice.Echo},
+		[]grpc.ServerOption{grpc.UnaryInterceptor(sc.UnaryServerInterceptor())})
+	adminServer := NewServer(sc)
+	t.Cleanup(adminServer.Close)
+	adminCC := testservice.ServeFunc(t, func(s *grpc.Server) {
+		pb.RegisterContractsAdminServer(s, adminServer)
+	}, nil)
+	return sc, frontCC, pb.NewContractsAdminClient(adminCC)
+}
+
+func TestListContracts(t *testing.T) {
+	sc, frontCC, client := serve(t)
+	ctx := context.Background()
+	for _, value := range []string{"a", "", "b"} {
+		if _, err := testservice.Invoke(ctx, frontCC, getMethod, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sc.SetServiceEnabled("test.Back", false)
+	sc.SetConditionEnabled(getMethod, "status", false)
+
+	resp, err := client.ListContracts(ctx, &pb.ListContractsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &pb.ListContractsResponse{Services: []*pb.Service{
+		{Name: "test.Back", Enabled: false},
+		{Name: "test.Front", Enabled: true, Methods: []*pb.Method{{
+			FullMethod: getMethod,
+			Conditions: []*pb.Condition{
+				{Name: "nonempty", Enabled: true, Passed: 2, Failed: 1},
+				{Name: "status", Enabled: false, Passed: 3},
+			},
+		}}},
+	}}
+	if !proto.Equal(resp, want) {
+		t.Errorf("ListContracts() = %v, want %v", resp, want)
+	}
+}
+
+func TestListViolations(t *testing.T) {
+	_, frontCC, client := serve(t)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		testservice.Invoke(ctx, frontCC, getMethod, "")
+	}
+
+	tests := []struct {
+		name     string
+		req      *pb.ListViolationsRequest
+		want     int
+		wantCode codes.Code
+	}{
+		{name: "all", req: &pb.ListViolationsRequest{}, want: 3},
+		{name: "limit", req: &pb.ListViolationsRequest{Limit: 2}, want: 2},
+		{name: "limit above count", req: &pb.ListViolationsRequest{Limit: 10}, want: 3},
+		{name: "method", req: &pb.ListViolationsRequest{FullMethod: getMethod, Limit: 1}, want: 1},
+		{name: "other method", req: &pb.ListViolationsRequest{FullMethod: "/test.Back/Lookup"}, want: 0},
+		{name: "negative limit", req: &pb.ListViolationsRequest{Limit: -1}, wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.ListViolations(ctx, tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("ListViolations() = %v, want code %s", err, tt.wantCode)
+			}
+			if got := len(resp.GetViolations()); got != tt.want {
+				t.Fatalf("got %d violations, want %d", got, tt.want)
+			}
+			for _, v := range resp.GetViolations() {
+				if v.Name != "nonempty" || v.FullMethod != getMethod || v.Error != "nonempty: empty request" || v.Time == nil {
+					t.Errorf("unexpected violation %v", v)
+				}
+			}
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	sc := contracts.NewServerContract(t.Log)
+	if err := sc.RegisterServiceContract(&contracts.ServiceContract{
+		ServiceName: "test.Front",
+		RPCContracts: []*contracts.UnaryRPCContract{{
+			MethodName:   "Get",
+			AllowedCodes: []codes.Code{},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cc := testservice.ServeMethods(t, "test.Front", map[string]testservice.Handler{"Get": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}}, []grpc.ServerOption{grpc.UnaryInterceptor(sc.UnaryServerInterceptor())})
+	ctx := context.Background()
+
+	open, closed := NewServer(sc), NewServer(sc)
+	closed.Close()
+	testservice.Invoke(ctx, cc, getMethod, "a")
+	open.Close()
+	testservice.Invoke(ctx, cc, getMethod, "b")
+
+	for _, tt := range []struct {
+		name string
+		s    *Server
+		want int
+	}{
+		{name: "closed before the call", s: closed, want: 0},
+		{name: "closed after the call", s: open, want: 1},
+	} {
+		resp, err := tt.s.ListViolations(ctx, &pb.ListViolationsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(resp.Violations); got != tt.want {
+			t.Errorf("%s: got %d violations, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRecentViolations(t *testing.T) {
+	tests := []struct {
+		name  string
+		count int
+		limit int32
+		want  []string
+	}{
+		{name: "none", count: 0},
+		{name: "most recent first", count: 3, want: []string{"v2", "v1", "v0"}},
+		{name: "limit", count: 3, limit: 1, want: []string{"v2"}},
+		{name: "full", count: MaxRecentViolations, limit: 2, want: []string{fmt.Sprintf("v%d", MaxRecentViolations-1), fmt.Sprintf("v%d", MaxRecentViolations-2)}},
+		{name: "overwritten", count: MaxRecentViolations + 5, limit: 2, want: []string{fmt.Sprintf("v%d", MaxRecentViolations+4), fmt.Sprintf("v%d", MaxRecentViolations+3)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer(contracts.NewServerContract(t.Log))
+			for i := 0; i < tt.count; i++ {
+				s.report(&contracts.Violation{Name: fmt.Sprintf("v%d", i), FullMethod: getMethod, Err: errors.New("violated")})
+			}
+			resp, err := s.ListViolations(context.Background(), &pb.ListViolationsRequest{Limit: tt.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, v := range resp.Violations {
+				got = append(got, v.Name)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			if all, _ := s.ListViolations(context.Background(), &pb.ListViolationsRequest{}); len(all.Violations) > MaxRecentViolations {
+				t.Errorf("kept %d violations, want at most %d", len(all.Violations), MaxRecentViolations)
+			}
+		})
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	sc, frontCC, client := serve(t)
+	ctx := context.Background()
+
+	if _, err := client.SetServiceEnabled(ctx, &pb.SetServiceEnabledRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetServiceEnabled() without a service = %v", err)
+	}
+	if _, err := client.SetConditionEnabled(ctx, &pb.SetConditionEnabledRequest{FullMethod: getMethod}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetConditionEnabled() without a name = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want bool
+	}{
+		{
+			name: "disable service",
+			call: func() error {
+				_, err := client.SetServiceEnabled(ctx, &pb.SetServiceEnabledRequest{Service: "test.Front", Enabled: false})
+				return err
+			},
+			want: false,
+		},
+		{
+			name: "enable service",
+			call: func() error {
+				_, err := client.SetServiceEnabled(ctx, &pb.SetServiceEnabledRequest{Service: "test.Front", Enabled: true})
+				return err
+			},
+			want: true,
+		},
+		{
+			name: "disable condition",
+			call: func() error {
+				_, err := client.SetConditionEnabled(ctx, &pb.SetConditionEnabledRequest{FullMethod: getMethod, Name: "nonempty"})
+				return err
+			},
+			want: false,
+		},
+		{
+			name: "enable condition",
+			call: func() error {
+				_, err := client.SetConditionEnabled(ctx, &pb.SetConditionEnabledRequest{FullMethod: getMethod, Name: "nonempty", Enabled: true})
+				return err
+			},
+			want: true,
+		},
+		{
+			name: "disable condition for all RPCs",
+			call: func() error {
+				_, err := client.SetConditionEnabled(ctx, &pb.SetConditionEnabledRequest{Name: "nonempty"})
+				return err
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatal(err)
+			}
+			before, _ := client.ListViolations(ctx, &pb.ListViolationsRequest{})
+			testservice.Invoke(ctx, frontCC, getMethod, "")
+			after, _ := client.ListViolations(ctx, &pb.ListViolationsRequest{})
+			if got := len(after.Violations) > len(before.Violations); got != tt.want {
+				t.Errorf("violation reported = %v, want %v", got, tt.want)
+			}
+			if got := sc.ServiceEnabled("test.Front") && sc.ConditionEnabled(getMethod, "nonempty"); got != tt.want {
+				t.Errorf("checking enabled = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViolationPolicy(t *testing.T) {
+	sc, _, client := serve(t)
+	ctx := context.Background()
+	tests := []struct {
+		policy   pb.ViolationPolicy
+		want     contracts.ViolationPolicy
+		wantCode codes.Code
+	}{
+		{policy: pb.ViolationPolicy_VIOLATION_POLICY_REJECT, want: contracts.RejectViolations},
+		{policy: pb.ViolationPolicy_VIOLATION_POLICY_IGNORE, want: contracts.IgnoreViolations},
+		{policy: pb.ViolationPolicy_VIOLATION_POLICY_UNSPECIFIED, want: contracts.IgnoreViolations, wantCode: codes.InvalidArgument},
+		{policy: pb.ViolationPolicy(42), want: contracts.IgnoreViolations, wantCode: codes.InvalidArgument},
+		{policy: pb.ViolationPolicy_VIOLATION_POLICY_LOG, want: contracts.LogViolations},
+	}
+
+	resp, err := client.GetViolationPolicy(ctx, &pb.GetViolationPolicyRequest{})
+	if err != nil || resp.Policy != pb.ViolationPolicy_VIOLATION_POLICY_LOG {
+		t.Fatalf("GetViolationPolicy() = %v, %v, want the log policy by default", resp, err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			_, err := client.SetViolationPolicy(ctx, &pb.SetViolationPolicyRequest{Policy: tt.policy})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("SetViolationPolicy() = %v, want code %s", err, tt.wantCode)
+			}
+			if got := sc.ViolationPolicy(); got != tt.want {
+				t.Errorf("policy is %s, want %s", got, tt.want)
+			}
+			resp, err := client.GetViolationPolicy(ctx, &pb.GetViolationPolicyRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Policy != policies[tt.want] {
+				t.Errorf("GetViolationPolicy() = %s, want %s", resp.Policy, policies[tt.want])
+			}
+		})
+	}
+}
@@ -0,0 +1,1019 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contracts/admin/adminpb/admin.proto
+
+package adminpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ViolationPolicy int32
+
+const (
+	ViolationPolicy_VIOLATION_POLICY_UNSPECIFIED ViolationPolicy = 0
+	// VIOLATION_POLICY_LOG logs and reports violations.
+	ViolationPolicy_VIOLATION_POLICY_LOG ViolationPolicy = 1
+	// VIOLATION_POLICY_REJECT additionally fails the RPCs that violate their contracts.
+	ViolationPolicy_VIOLATION_POLICY_REJECT ViolationPolicy = 2
+	// VIOLATION_POLICY_IGNORE disables checking contracts.
+	ViolationPolicy_VIOLATION_POLICY_IGNORE ViolationPolicy = 3
+)
+
+// Enum value maps for ViolationPolicy.
+var (
+	ViolationPolicy_name = map[int32]string{
+		0: "VIOLATION_POLICY_UNSPECIFIED",
+		1: "VIOLATION_POLICY_LOG",
+		2: "VIOLATION_POLICY_REJECT",
+		3: "VIOLATION_POLICY_IGNORE",
+	}
+	ViolationPolicy_value = map[string]int32{
+		"VIOLATION_POLICY_UNSPECIFIED": 0,
+		"VIOLATION_POLICY_LOG":         1,
+		"VIOLATION_POLICY_REJECT":      2,
+		"VIOLATION_POLICY_IGNORE":      3,
+	}
+)
+
+func (x ViolationPolicy) Enum() *ViolationPolicy {
+	p := new(ViolationPolicy)
+	*p = x
+	return p
+}
+
+func (x ViolationPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ViolationPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_admin_adminpb_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ViolationPolicy) Type() protoreflect.EnumType {
+	return &file_contracts_admin_adminpb_admin_proto_enumTypes[0]
+}
+
+func (x ViolationPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ViolationPolicy.Descriptor instead.
+func (ViolationPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type Service struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the service, i.e., package.service.
+	Name          string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool      `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Methods       []*Method `protobuf:"bytes,3,rep,name=methods,proto3" json:"methods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Service) Reset() {
+	*x = Service{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Service) ProtoMessage() {}
+
+func (x *Service) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Service.ProtoReflect.Descriptor instead.
+func (*Service) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Service) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Service) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Service) GetMethods() []*Method {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type Method struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// full_method is the full RPC method string, i.e., /package.service/method.
+	FullMethod    string       `protobuf:"bytes,1,opt,name=full_method,json=fullMethod,proto3" json:"full_method,omitempty"`
+	Conditions    []*Condition `protobuf:"bytes,2,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Method) Reset() {
+	*x = Method{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Method) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Method) ProtoMessage() {}
+
+func (x *Method) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Method.ProtoReflect.Descriptor instead.
+func (*Method) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Method) GetFullMethod() string {
+	if x != nil {
+		return x.FullMethod
+	}
+	return ""
+}
+
+func (x *Method) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+type Condition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the condition, as reported in its violations.
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// passed and failed are the number of times the condition was checked
+	// and held or was violated, respectively.
+	Passed        uint64 `protobuf:"varint,3,opt,name=passed,proto3" json:"passed,omitempty"`
+	Failed        uint64 `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Condition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Condition) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Condition) GetPassed() uint64 {
+	if x != nil {
+		return x.Passed
+	}
+	return 0
+}
+
+func (x *Condition) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type Violation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the violated condition.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// full_method is the served RPC, if any.
+	FullMethod string `protobuf:"bytes,2,opt,name=full_method,json=fullMethod,proto3" json:"full_method,omitempty"`
+	// call is the called RPC if the violation concerns a downstream call.
+	Call          string                 `protobuf:"bytes,3,opt,name=call,proto3" json:"call,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Violation) Reset() {
+	*x = Violation{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Violation) ProtoMessage() {}
+
+func (x *Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Violation.ProtoReflect.Descriptor instead.
+func (*Violation) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Violation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Violation) GetFullMethod() string {
+	if x != nil {
+		return x.FullMethod
+	}
+	return ""
+}
+
+func (x *Violation) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *Violation) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Violation) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ListContractsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContractsRequest) Reset() {
+	*x = ListContractsRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContractsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContractsRequest) ProtoMessage() {}
+
+func (x *ListContractsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContractsRequest.ProtoReflect.Descriptor instead.
+func (*ListContractsRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{4}
+}
+
+type ListContractsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*Service             `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContractsResponse) Reset() {
+	*x = ListContractsResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContractsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContractsResponse) ProtoMessage() {}
+
+func (x *ListContractsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContractsResponse.ProtoReflect.Descriptor instead.
+func (*ListContractsResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListContractsResponse) GetServices() []*Service {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type ListViolationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// full_method limits the violations to the ones of a served RPC, if set.
+	FullMethod string `protobuf:"bytes,1,opt,name=full_method,json=fullMethod,proto3" json:"full_method,omitempty"`
+	// limit is the maximum number of violations to return. Zero means no limit.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViolationsRequest) Reset() {
+	*x = ListViolationsRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViolationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViolationsRequest) ProtoMessage() {}
+
+func (x *ListViolationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViolationsRequest.ProtoReflect.Descriptor instead.
+func (*ListViolationsRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListViolationsRequest) GetFullMethod() string {
+	if x != nil {
+		return x.FullMethod
+	}
+	return ""
+}
+
+func (x *ListViolationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListViolationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Violations    []*Violation           `protobuf:"bytes,1,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListViolationsResponse) Reset() {
+	*x = ListViolationsResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListViolationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListViolationsResponse) ProtoMessage() {}
+
+func (x *ListViolationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListViolationsResponse.ProtoReflect.Descriptor instead.
+func (*ListViolationsResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListViolationsResponse) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type SetServiceEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServiceEnabledRequest) Reset() {
+	*x = SetServiceEnabledRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServiceEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServiceEnabledRequest) ProtoMessage() {}
+
+func (x *SetServiceEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServiceEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetServiceEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetServiceEnabledRequest) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *SetServiceEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetServiceEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetServiceEnabledResponse) Reset() {
+	*x = SetServiceEnabledResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetServiceEnabledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetServiceEnabledResponse) ProtoMessage() {}
+
+func (x *SetServiceEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetServiceEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetServiceEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type SetConditionEnabledRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// full_method is the served RPC. If it is empty, the condition is enabled
+	// or disabled for all RPCs.
+	FullMethod    string `protobuf:"bytes,1,opt,name=full_method,json=fullMethod,proto3" json:"full_method,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled       bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConditionEnabledRequest) Reset() {
+	*x = SetConditionEnabledRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConditionEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConditionEnabledRequest) ProtoMessage() {}
+
+func (x *SetConditionEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConditionEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetConditionEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetConditionEnabledRequest) GetFullMethod() string {
+	if x != nil {
+		return x.FullMethod
+	}
+	return ""
+}
+
+func (x *SetConditionEnabledRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetConditionEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetConditionEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetConditionEnabledResponse) Reset() {
+	*x = SetConditionEnabledResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetConditionEnabledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetConditionEnabledResponse) ProtoMessage() {}
+
+func (x *SetConditionEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetConditionEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetConditionEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{11}
+}
+
+type GetViolationPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViolationPolicyRequest) Reset() {
+	*x = GetViolationPolicyRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViolationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViolationPolicyRequest) ProtoMessage() {}
+
+func (x *GetViolationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViolationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetViolationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type GetViolationPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        ViolationPolicy        `protobuf:"varint,1,opt,name=policy,proto3,enum=contracts.admin.ViolationPolicy" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetViolationPolicyResponse) Reset() {
+	*x = GetViolationPolicyResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetViolationPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetViolationPolicyResponse) ProtoMessage() {}
+
+func (x *GetViolationPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetViolationPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetViolationPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetViolationPolicyResponse) GetPolicy() ViolationPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return ViolationPolicy_VIOLATION_POLICY_UNSPECIFIED
+}
+
+type SetViolationPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        ViolationPolicy        `protobuf:"varint,1,opt,name=policy,proto3,enum=contracts.admin.ViolationPolicy" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetViolationPolicyRequest) Reset() {
+	*x = SetViolationPolicyRequest{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetViolationPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetViolationPolicyRequest) ProtoMessage() {}
+
+func (x *SetViolationPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetViolationPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetViolationPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetViolationPolicyRequest) GetPolicy() ViolationPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return ViolationPolicy_VIOLATION_POLICY_UNSPECIFIED
+}
+
+type SetViolationPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetViolationPolicyResponse) Reset() {
+	*x = SetViolationPolicyResponse{}
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetViolationPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetViolationPolicyResponse) ProtoMessage() {}
+
+func (x *SetViolationPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_admin_adminpb_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetViolationPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetViolationPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_admin_adminpb_admin_proto_rawDescGZIP(), []int{15}
+}
+
+var File_contracts_admin_adminpb_admin_proto protoreflect.FileDescriptor
+
+const file_contracts_admin_adminpb_admin_proto_rawDesc = "" +
+	"\n" +
+	"#contracts/admin/adminpb/admin.proto\x12\x0fcontracts.admin\x1a\x1fgoogle/protobuf/timestamp.proto\"j\n" +
+	"\aService\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x121\n" +
+	"\amethods\x18\x03 \x03(\v2\x17.contracts.admin.MethodR\amethods\"e\n" +
+	"\x06Method\x12\x1f\n" +
+	"\vfull_method\x18\x01 \x01(\tR\n" +
+	"fullMethod\x12:\n" +
+	"\n" +
+	"conditions\x18\x02 \x03(\v2\x1a.contracts.admin.ConditionR\n" +
+	"conditions\"i\n" +
+	"\tCondition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06passed\x18\x03 \x01(\x04R\x06passed\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x04R\x06failed\"\x9a\x01\n" +
+	"\tViolation\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vfull_method\x18\x02 \x01(\tR\n" +
+	"fullMethod\x12\x12\n" +
+	"\x04call\x18\x03 \x01(\tR\x04call\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\x16\n" +
+	"\x14ListContractsRequest\"M\n" +
+	"\x15ListContractsResponse\x124\n" +
+	"\bservices\x18\x01 \x03(\v2\x18.contracts.admin.ServiceR\bservices\"N\n" +
+	"\x15ListViolationsRequest\x12\x1f\n" +
+	"\vfull_method\x18\x01 \x01(\tR\n" +
+	"fullMethod\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"T\n" +
+	"\x16ListViolationsResponse\x12:\n" +
+	"\n" +
+	"violations\x18\x01 \x03(\v2\x1a.contracts.admin.ViolationR\n" +
+	"violations\"N\n" +
+	"\x18SetServiceEnabledRequest\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\x1b\n" +
+	"\x19SetServiceEnabledResponse\"k\n" +
+	"\x1aSetConditionEnabledRequest\x12\x1f\n" +
+	"\vfull_method\x18\x01 \x01(\tR\n" +
+	"fullMethod\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\"\x1d\n" +
+	"\x1bSetConditionEnabledResponse\"\x1b\n" +
+	"\x19GetViolationPolicyRequest\"V\n" +
+	"\x1aGetViolationPolicyResponse\x128\n" +
+	"\x06policy\x18\x01 \x01(\x0e2 .contracts.admin.ViolationPolicyR\x06policy\"U\n" +
+	"\x19SetViolationPolicyRequest\x128\n" +
+	"\x06policy\x18\x01 \x01(\x0e2 .contracts.admin.ViolationPolicyR\x06policy\"\x1c\n" +
+	"\x1aSetViolationPolicyResponse*\x87\x01\n" +
+	"\x0fViolationPolicy\x12 \n" +
+	"\x1cVIOLATION_POLICY_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14VIOLATION_POLICY_LOG\x10\x01\x12\x1b\n" +
+	"\x17VIOLATION_POLICY_REJECT\x10\x02\x12\x1b\n" +
+	"\x17VIOLATION_POLICY_IGNORE\x10\x032\x9b\x05\n" +
+	"\x0eContractsAdmin\x12`\n" +
+	"\rListContracts\x12%.contracts.admin.ListContractsRequest\x1a&.contracts.admin.ListContractsResponse\"\x00\x12c\n" +
+	"\x0eListViolations\x12&.contracts.admin.ListViolationsRequest\x1a'.contracts.admin.ListViolationsResponse\"\x00\x12l\n" +
+	"\x11SetServiceEnabled\x12).contracts.admin.SetServiceEnabledRequest\x1a*.contracts.admin.SetServiceEnabledResponse\"\x00\x12r\n" +
+	"\x13SetConditionEnabled\x12+.contracts.admin.SetConditionEnabledRequest\x1a,.contracts.admin.SetConditionEnabledResponse\"\x00\x12o\n" +
+	"\x12GetViolationPolicy\x12*.contracts.admin.GetViolationPolicyRequest\x1a+.contracts.admin.GetViolationPolicyResponse\"\x00\x12o\n" +
+	"\x12SetViolationPolicy\x12*.contracts.admin.SetViolationPolicyRequest\x1a+.contracts.admin.SetViolationPolicyResponse\"\x00B>Z<github.com/shayanh/grpc-go-contracts/contracts/admin/adminpbb\x06proto3"
+
+var (
+	file_contracts_admin_adminpb_admin_proto_rawDescOnce sync.Once
+	file_contracts_admin_adminpb_admin_proto_rawDescData []byte
+)
+
+func file_contracts_admin_adminpb_admin_proto_rawDescGZIP() []byte {
+	file_contracts_admin_adminpb_admin_proto_rawDescOnce.Do(func() {
+		file_contracts_admin_adminpb_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_admin_adminpb_admin_proto_rawDesc), len(file_contracts_admin_adminpb_admin_proto_rawDesc)))
+	})
+	return file_contracts_admin_adminpb_admin_proto_rawDescData
+}
+
+var file_contracts_admin_adminpb_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_contracts_admin_adminpb_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_contracts_admin_adminpb_admin_proto_goTypes = []any{
+	(ViolationPolicy)(0),                // 0: contracts.admin.ViolationPolicy
+	(*Service)(nil),                     // 1: contracts.admin.Service
+	(*Method)(nil),                      // 2: contracts.admin.Method
+	(*Condition)(nil),                   // 3: contracts.admin.Condition
+	(*Violation)(nil),                   // 4: contracts.admin.Violation
+	(*ListContractsRequest)(nil),        // 5: contracts.admin.ListContractsRequest
+	(*ListContractsResponse)(nil),       // 6: contracts.admin.ListContractsResponse
+	(*ListViolationsRequest)(nil),       // 7: contracts.admin.ListViolationsRequest
+	(*ListViolationsResponse)(nil),      // 8: contracts.admin.ListViolationsResponse
+	(*SetServiceEnabledRequest)(nil),    // 9: contracts.admin.SetServiceEnabledRequest
+	(*SetServiceEnabledResponse)(nil),   // 10: contracts.admin.SetServiceEnabledResponse
+	(*SetConditionEnabledRequest)(nil),  // 11: contracts.admin.SetConditionEnabledRequest
+	(*SetConditionEnabledResponse)(nil), // 12: contracts.admin.SetConditionEnabledResponse
+	(*GetViolationPolicyRequest)(nil),   // 13: contracts.admin.GetViolationPolicyRequest
+	(*GetViolationPolicyResponse)(nil),  // 14: contracts.admin.GetViolationPolicyResponse
+	(*SetViolationPolicyRequest)(nil),   // 15: contracts.admin.SetViolationPolicyRequest
+	(*SetViolationPolicyResponse)(nil),  // 16: contracts.admin.SetViolationPolicyResponse
+	(*timestamppb.Timestamp)(nil),       // 17: google.protobuf.Timestamp
+}
+var file_contracts_admin_adminpb_admin_proto_depIdxs = []int32{
+	2,  // 0: contracts.admin.Service.methods:type_name -> contracts.admin.Method
+	3,  // 1: contracts.admin.Method.conditions:type_name -> contracts.admin.Condition
+	17, // 2: contracts.admin.Violation.time:type_name -> google.protobuf.Timestamp
+	1,  // 3: contracts.admin.ListContractsResponse.services:type_name -> contracts.admin.Service
+	4,  // 4: contracts.admin.ListViolationsResponse.violations:type_name -> contracts.admin.Violation
+	0,  // 5: contracts.admin.GetViolationPolicyResponse.policy:type_name -> contracts.admin.ViolationPolicy
+	0,  // 6: contracts.admin.SetViolationPolicyRequest.policy:type_name -> contracts.admin.ViolationPolicy
+	5,  // 7: contracts.admin.ContractsAdmin.ListContracts:input_type -> contracts.admin.ListContractsRequest
+	7,  // 8: contracts.admin.ContractsAdmin.ListViolations:input_type -> contracts.admin.ListViolationsRequest
+	9,  // 9: contracts.admin.ContractsAdmin.SetServiceEnabled:input_type -> contracts.admin.SetServiceEnabledRequest
+	11, // 10: contracts.admin.ContractsAdmin.SetConditionEnabled:input_type -> contracts.admin.SetConditionEnabledRequest
+	13, // 11: contracts.admin.ContractsAdmin.GetViolationPolicy:input_type -> contracts.admin.GetViolationPolicyRequest
+	15, // 12: contracts.admin.ContractsAdmin.SetViolationPolicy:input_type -> contracts.admin.SetViolationPolicyRequest
+	6,  // 13: contracts.admin.ContractsAdmin.ListContracts:output_type -> contracts.admin.ListContractsResponse
+	8,  // 14: contracts.admin.ContractsAdmin.ListViolations:output_type -> contracts.admin.ListViolationsResponse
+	10, // 15: contracts.admin.ContractsAdmin.SetServiceEnabled:output_type -> contracts.admin.SetServiceEnabledResponse
+	12, // 16: contracts.admin.ContractsAdmin.SetConditionEnabled:output_type -> contracts.admin.SetConditionEnabledResponse
+	14, // 17: contracts.admin.ContractsAdmin.GetViolationPolicy:output_type -> contracts.admin.GetViolationPolicyResponse
+	16, // 18: contracts.admin.ContractsAdmin.SetViolationPolicy:output_type -> contracts.admin.SetViolationPolicyResponse
+	13, // [13:19] is the sub-list for method output_type
+	7,  // [7:13] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_contracts_admin_adminpb_admin_proto_init() }
+func file_contracts_admin_adminpb_admin_proto_init() {
+	if File_contracts_admin_adminpb_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_admin_adminpb_admin_proto_rawDesc), len(file_contracts_admin_adminpb_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contracts_admin_adminpb_admin_proto_goTypes,
+		DependencyIndexes: file_contracts_admin_adminpb_admin_proto_depIdxs,
+		EnumInfos:         file_contracts_admin_adminpb_admin_proto_enumTypes,
+		MessageInfos:      file_contracts_admin_adminpb_admin_proto_msgTypes,
+	}.Build()
+	File_contracts_admin_adminpb_admin_proto = out.File
+	file_contracts_admin_adminpb_admin_proto_goTypes = nil
+	file_contracts_admin_adminpb_admin_proto_depIdxs = nil
+}
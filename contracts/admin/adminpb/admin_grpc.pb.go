@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: contracts/admin/adminpb/admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ContractsAdmin_ListContracts_FullMethodName       = "/contracts.admin.ContractsAdmin/ListContracts"
+	ContractsAdmin_ListViolations_FullMethodName      = "/contracts.admin.ContractsAdmin/ListViolations"
+	ContractsAdmin_SetServiceEnabled_FullMethodName   = "/contracts.admin.ContractsAdmin/SetServiceEnabled"
+	ContractsAdmin_SetConditionEnabled_FullMethodName = "/contracts.admin.ContractsAdmin/SetConditionEnabled"
+	ContractsAdmin_GetViolationPolicy_FullMethodName  = "/contracts.admin.ContractsAdmin/GetViolationPolicy"
+	ContractsAdmin_SetViolationPolicy_FullMethodName  = "/contracts.admin.ContractsAdmin/SetViolationPolicy"
+)
+
+// ContractsAdminClient is the client API for ContractsAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ContractsAdmin inspects and controls the contracts of a server at runtime.
+type ContractsAdminClient interface {
+	// ListContracts lists the registered service contracts and the state of
+	// the conditions of their methods.
+	ListContracts(ctx context.Context, in *ListContractsRequest, opts ...grpc.CallOption) (*ListContractsResponse, error)
+	// ListViolations lists the recent contract violations, most recent first.
+	ListViolations(ctx context.Context, in *ListViolationsRequest, opts ...grpc.CallOption) (*ListViolationsResponse, error)
+	// SetServiceEnabled enables or disables checking the contracts of a service.
+	SetServiceEnabled(ctx context.Context, in *SetServiceEnabledRequest, opts ...grpc.CallOption) (*SetServiceEnabledResponse, error)
+	// SetConditionEnabled enables or disables a condition.
+	SetConditionEnabled(ctx context.Context, in *SetConditionEnabledRequest, opts ...grpc.CallOption) (*SetConditionEnabledResponse, error)
+	// GetViolationPolicy returns the current violation policy.
+	GetViolationPolicy(ctx context.Context, in *GetViolationPolicyRequest, opts ...grpc.CallOption) (*GetViolationPolicyResponse, error)
+	// SetViolationPolicy changes the violation policy.
+	SetViolationPolicy(ctx context.Context, in *SetViolationPolicyRequest, opts ...grpc.CallOption) (*SetViolationPolicyResponse, error)
+}
+
+type contractsAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewContractsAdminClient(cc grpc.ClientConnInterface) ContractsAdminClient {
+	return &contractsAdminClient{cc}
+}
+
+func (c *contractsAdminClient) ListContracts(ctx context.Context, in *ListContractsRequest, opts ...grpc.CallOption) (*ListContractsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListContractsResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_ListContracts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contractsAdminClient) ListViolations(ctx context.Context, in *ListViolationsRequest, opts ...grpc.CallOption) (*ListViolationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListViolationsResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_ListViolations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contractsAdminClient) SetServiceEnabled(ctx context.Context, in *SetServiceEnabledRequest, opts ...grpc.CallOption) (*SetServiceEnabledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetServiceEnabledResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_SetServiceEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contractsAdminClient) SetConditionEnabled(ctx context.Context, in *SetConditionEnabledRequest, opts ...grpc.CallOption) (*SetConditionEnabledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetConditionEnabledResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_SetConditionEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contractsAdminClient) GetViolationPolicy(ctx context.Context, in *GetViolationPolicyRequest, opts ...grpc.CallOption) (*GetViolationPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetViolationPolicyResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_GetViolationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *contractsAdminClient) SetViolationPolicy(ctx context.Context, in *SetViolationPolicyRequest, opts ...grpc.CallOption) (*SetViolationPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetViolationPolicyResponse)
+	err := c.cc.Invoke(ctx, ContractsAdmin_SetViolationPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ContractsAdminServer is the server API for ContractsAdmin service.
+// All implementations must embed UnimplementedContractsAdminServer
+// for forward compatibility.
+//
+// ContractsAdmin inspects and controls the contracts of a server at runtime.
+type ContractsAdminServer interface {
+	// ListContracts lists the registered service contracts and the state of
+	// the conditions of their methods.
+	ListContracts(context.Context, *ListContractsRequest) (*ListContractsResponse, error)
+	// ListViolations lists the recent contract violations, most recent first.
+	ListViolations(context.Context, *ListViolationsRequest) (*ListViolationsResponse, error)
+	// SetServiceEnabled enables or disables checking the contracts of a service.
+	SetServiceEnabled(context.Context, *SetServiceEnabledRequest) (*SetServiceEnabledResponse, error)
+	// SetConditionEnabled enables or disables a condition.
+	SetConditionEnabled(context.Context, *SetConditionEnabledRequest) (*SetConditionEnabledResponse, error)
+	// GetViolationPolicy returns the current violation policy.
+	GetViolationPolicy(context.Context, *GetViolationPolicyRequest) (*GetViolationPolicyResponse, error)
+	// SetViolationPolicy changes the violation policy.
+	SetViolationPolicy(context.Context, *SetViolationPolicyRequest) (*SetViolationPolicyResponse, error)
+	mustEmbedUnimplementedContractsAdminServer()
+}
+
+// UnimplementedContractsAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedContractsAdminServer struct{}
+
+func (UnimplementedContractsAdminServer) ListContracts(context.Context, *ListContractsRequest) (*ListContractsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListContracts not implemented")
+}
+func (UnimplementedContractsAdminServer) ListViolations(context.Context, *ListViolationsRequest) (*ListViolationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListViolations not implemented")
+}
+func (UnimplementedContractsAdminServer) SetServiceEnabled(context.Context, *SetServiceEnabledRequest) (*SetServiceEnabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetServiceEnabled not implemented")
+}
+func (UnimplementedContractsAdminServer) SetConditionEnabled(context.Context, *SetConditionEnabledRequest) (*SetConditionEnabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetConditionEnabled not implemented")
+}
+func (UnimplementedContractsAdminServer) GetViolationPolicy(context.Context, *GetViolationPolicyRequest) (*GetViolationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetViolationPolicy not implemented")
+}
+func (UnimplementedContractsAdminServer) SetViolationPolicy(context.Context, *SetViolationPolicyRequest) (*SetViolationPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetViolationPolicy not implemented")
+}
+func (UnimplementedContractsAdminServer) mustEmbedUnimplementedContractsAdminServer() {}
+func (UnimplementedContractsAdminServer) testEmbeddedByValue()                        {}
+
+// UnsafeContractsAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ContractsAdminServer will
+// result in compilation errors.
+type UnsafeContractsAdminServer interface {
+	mustEmbedUnimplementedContractsAdminServer()
+}
+
+func RegisterContractsAdminServer(s grpc.ServiceRegistrar, srv ContractsAdminServer) {
+	// If the following call pancis, it indicates UnimplementedContractsAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ContractsAdmin_ServiceDesc, srv)
+}
+
+func _ContractsAdmin_ListContracts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListContractsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).ListContracts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_ListContracts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).ListContracts(ctx, req.(*ListContractsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContractsAdmin_ListViolations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListViolationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).ListViolations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_ListViolations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).ListViolations(ctx, req.(*ListViolationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContractsAdmin_SetServiceEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetServiceEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).SetServiceEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_SetServiceEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).SetServiceEnabled(ctx, req.(*SetServiceEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContractsAdmin_SetConditionEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConditionEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).SetConditionEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_SetConditionEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).SetConditionEnabled(ctx, req.(*SetConditionEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContractsAdmin_GetViolationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetViolationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).GetViolationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_GetViolationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).GetViolationPolicy(ctx, req.(*GetViolationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContractsAdmin_SetViolationPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetViolationPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContractsAdminServer).SetViolationPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ContractsAdmin_SetViolationPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContractsAdminServer).SetViolationPolicy(ctx, req.(*SetViolationPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ContractsAdmin_ServiceDesc is the grpc.ServiceDesc for ContractsAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ContractsAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "contracts.admin.ContractsAdmin",
+	HandlerType: (*ContractsAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListContracts",
+			Handler:    _ContractsAdmin_ListContracts_Handler,
+		},
+		{
+			MethodName: "ListViolations",
+			Handler:    _ContractsAdmin_ListViolations_Handler,
+		},
+		{
+			MethodName: "SetServiceEnabled",
+			Handler:    _ContractsAdmin_SetServiceEnabled_Handler,
+		},
+		{
+			MethodName: "SetConditionEnabled",
+			Handler:    _ContractsAdmin_SetConditionEnabled_Handler,
+		},
+		{
+			MethodName: "GetViolationPolicy",
+			Handler:    _ContractsAdmin_GetViolationPolicy_Handler,
+		},
+		{
+			MethodName: "SetViolationPolicy",
+			Handler:    _ContractsAdmin_SetViolationPolicy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "contracts/admin/adminpb/admin.proto",
+}
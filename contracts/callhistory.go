@@ -3,10 +3,18 @@ package contracts
 import (
 	"errors"
 	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // UnaryRPCCall represents an RPC call and its details.
 type UnaryRPCCall struct {
+	// ID identifies the call within its call tree.
+	ID string
 	// FullMethod is the full RPC method string, i.e., /package.service/method.
 	FullMethod string
 	// Request is the body of the RPC request.
@@ -15,11 +23,39 @@ type UnaryRPCCall struct {
 	Response interface{}
 	// Error is the error that returned with the RPC response.
 	Error error
+	// RequestMetadata is the outgoing metadata sent with the RPC request.
+	RequestMetadata metadata.MD
+	// Header is the header metadata received from the server.
+	Header metadata.MD
+	// Trailer is the trailer metadata received from the server.
+	Trailer metadata.MD
 	// Order represents the invocation time of RPCs in ascending order.
 	Order int
+	// StartTime is the time the call was invoked.
+	StartTime time.Time
+	// EndTime is the time the call returned.
+	EndTime time.Time
+	// Duration is the time it took for the call to return.
+	Duration time.Duration
+	// Deadline is the deadline of the call's context. It is the zero time if
+	// the call had no deadline.
+	Deadline time.Time
+	// Attempts is the number of attempts made to complete the call. Retries
+	// are only counted if the client uses ServerContract.StatsHandler, otherwise
+	// it is always 1.
+	Attempts int
+	// Parent is the call that this call is nested in, if any. See RPCCallHistory.Tree.
+	// A parent made by another request may not have completed yet.
+	Parent *UnaryRPCCall
+	// Children are the completed calls nested in this call, in invocation
+	// order. Calls made with WithParent are added to the children of a call
+	// even after it completed.
+	Children CallSet
 }
 
 // RPCCallHistory lets you have access to the RPC calls made during an RPC lifetime.
+// Calls are added to the history once they complete. The zero RPCCallHistory
+// has no calls.
 type RPCCallHistory struct {
 	requestID string
 	sc        *ServerContract
@@ -28,8 +64,14 @@ type RPCCallHistory struct {
 // CallSet is a set of UnaryRPCCalls that provides APIs for simpler usage.
 type CallSet []*UnaryRPCCall
 
-// All returns all invoked RPCs.
+// ErrNoCall is returned when a requested RPC call does not exist in a call set.
+var ErrNoCall = errors.New("no call exists")
+
+// All returns all invoked RPCs in invocation order.
 func (h *RPCCallHistory) All() CallSet {
+	if h.sc == nil {
+		return nil
+	}
 	h.sc.callsLock.RLock()
 	defer h.sc.callsLock.RUnlock()
 
@@ -37,23 +79,72 @@ func (h *RPCCallHistory) All() CallSet {
 	for _, calls := range h.sc.unaryRPCCalls[h.requestID] {
 		res = append(res, calls...)
 	}
+	res.sortByOrder()
+	return res
+}
+
+// Tree returns the invoked RPCs as a tree. The returned call set contains the
+// top-level calls, i.e., calls that are not nested in another call of the
+// request, in invocation order.
+//
+// A call is nested in another call if it is made with a context carrying the
+// other call as its parent, see WithParent. Moreover, if the ServerContract
+// reports to a collector and also serves a recorded call, e.g., the services
+// run in the same process, the calls made while serving it are nested in the
+// recorded call.
+func (h *RPCCallHistory) Tree() CallSet {
+	if h.sc == nil {
+		return nil
+	}
+	h.sc.callsLock.RLock()
+	defer h.sc.callsLock.RUnlock()
+
+	calls := h.sc.unaryRPCCalls[h.requestID]
+	var res CallSet
+	for _, methodCalls := range calls {
+		for _, call := range methodCalls {
+			if call.Parent == nil || !containsCall(calls[call.Parent.FullMethod], call.Parent) {
+				res = append(res, call)
+			}
+		}
+	}
+	res.sortByOrder()
 	return res
 }
 
-// Filter returns RPC calls to the given method.
+func containsCall(cs CallSet, call *UnaryRPCCall) bool {
+	for _, c := range cs {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns RPC calls to the given method in invocation order.
 // serviceName is name the gRPC service, i.e., package.service.
 // methodName is the method name only, without the service name or package name.
 func (h *RPCCallHistory) Filter(serviceName, methodName string) CallSet {
+	if h.sc == nil {
+		return nil
+	}
 	h.sc.callsLock.RLock()
 	defer h.sc.callsLock.RUnlock()
 
 	fullMethod := getFullMethodName(serviceName, methodName)
 	src := h.sc.unaryRPCCalls[h.requestID][fullMethod]
-	res := make([]*UnaryRPCCall, len(src))
+	res := make(CallSet, len(src))
 	copy(res, src)
+	res.sortByOrder()
 	return res
 }
 
+// FilterService returns RPC calls to all methods of the given service in invocation order.
+// serviceName is name the gRPC service, i.e., package.service.
+func (h *RPCCallHistory) FilterService(serviceName string) CallSet {
+	return h.All().FilterService(serviceName)
+}
+
 // Successful filters successful RPC calls and returns them.
 func (cs CallSet) Successful() CallSet {
 	var res CallSet
@@ -65,12 +156,19 @@ func (cs CallSet) Successful() CallSet {
 	return res
 }
 
-// Ordered sorts RPC calls in the call set by their invocation time.
+// Ordered returns a copy of the call set sorted by the invocation time of the
+// calls. The call set itself is not modified.
 func (cs CallSet) Ordered() CallSet {
-	sort.Slice(cs, func(i, j int) bool {
+	res := make(CallSet, len(cs))
+	copy(res, cs)
+	res.sortByOrder()
+	return res
+}
+
+func (cs CallSet) sortByOrder() {
+	sort.SliceStable(cs, func(i, j int) bool {
 		return cs[i].Order < cs[j].Order
 	})
-	return cs
 }
 
 // Empty returns true if the call set is empty.
@@ -83,10 +181,125 @@ func (cs CallSet) Count() int {
 	return len(cs)
 }
 
-// First returns the first RPC call in the call set (if exists).
+// First returns the first RPC call in the call set. It returns ErrNoCall if the call set is empty.
 func (cs CallSet) First() (*UnaryRPCCall, error) {
 	if cs.Empty() {
-		return nil, errors.New("No call exists")
+		return nil, ErrNoCall
 	}
 	return cs[0], nil
 }
+
+// Failed filters failed RPC calls and returns them.
+func (cs CallSet) Failed() CallSet {
+	return cs.Where(func(call *UnaryRPCCall) bool {
+		return call.Error != nil
+	})
+}
+
+// WithCode filters RPC calls that returned the given status code and returns them.
+// Successful calls have the codes.OK code.
+func (cs CallSet) WithCode(code codes.Code) CallSet {
+	return cs.Where(func(call *UnaryRPCCall) bool {
+		return status.Code(call.Error) == code
+	})
+}
+
+// FilterService filters RPC calls to all methods of the given service and returns them.
+// serviceName is name the gRPC service, i.e., package.service.
+func (cs CallSet) FilterService(serviceName string) CallSet {
+	prefix := "/" + serviceName + "/"
+	return cs.Where(func(call *UnaryRPCCall) bool {
+		return strings.HasPrefix(call.FullMethod, prefix)
+	})
+}
+
+// Where filters RPC calls that satisfy the given predicate and returns them.
+func (cs CallSet) Where(pred func(*UnaryRPCCall) bool) CallSet {
+	var res CallSet
+	for _, call := range cs {
+		if pred(call) {
+			res = append(res, call)
+		}
+	}
+	return res
+}
+
+// Any returns true if at least one RPC call in the call set satisfies the predicate.
+func (cs CallSet) Any(pred func(*UnaryRPCCall) bool) bool {
+	for _, call := range cs {
+		if pred(call) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if all RPC calls in the call set satisfy the predicate.
+// It returns true for an empty call set.
+func (cs CallSet) All(pred func(*UnaryRPCCall) bool) bool {
+	for _, call := range cs {
+		if !pred(call) {
+			return false
+		}
+	}
+	return true
+}
+
+// Last returns the last RPC call in the call set. It returns ErrNoCall if the call set is empty.
+func (cs CallSet) Last() (*UnaryRPCCall, error) {
+	if cs.Empty() {
+		return nil, ErrNoCall
+	}
+	return cs[len(cs)-1], nil
+}
+
+// Nth returns the i-th RPC call in the call set, starting from zero. It returns
+// ErrNoCall if the call set has no such call.
+func (cs CallSet) Nth(i int) (*UnaryRPCCall, error) {
+	if i < 0 || i >= len(cs) {
+		return nil, ErrNoCall
+	}
+	return cs[i], nil
+}
+
+// Methods returns the full methods of the RPC calls in the call set without
+// duplicates, in the order of their first appearance.
+func (cs CallSet) Methods() []string {
+	var res []string
+	seen := make(map[string]bool)
+	for _, call := range cs {
+		if !seen[call.FullMethod] {
+			seen[call.FullMethod] = true
+			res = append(res, call.FullMethod)
+		}
+	}
+	return res
+}
+
+// Requests returns the requests of the RPC calls in the call set that are of
+// type T, e.g., contracts.Requests[*pb.AuthenticateRequest](cs).
+func Requests[T any](cs CallSet) []T {
+	var res []T
+	for _, call := range cs {
+		if req, ok := call.Request.(T); ok {
+			res = append(res, req)
+		}
+	}
+	return res
+}
+
+// Responses returns the responses of the RPC calls in the call set that are of
+// type T, e.g., contracts.Responses[*pb.AuthenticateResponse](cs). Responses
+// of failed calls are skipped.
+func Responses[T any](cs CallSet) []T {
+	var res []T
+	for _, call := range cs {
+		if call.Error != nil {
+			continue
+		}
+		if resp, ok := call.Response.(T); ok {
+			res = append(res, resp)
+		}
+	}
+	return res
+}
@@ -0,0 +1,542 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// dumpTree returns a call tree in the form of "Lookup(Read), Read".
+func dumpTree(cs CallSet) string {
+	var res []string
+	for _, call := range cs {
+		s := call.FullMethod[strings.LastIndex(call.FullMethod, "/")+1:]
+		if len(call.Children) > 0 {
+			s += "(" + dumpTree(call.Children) + ")"
+		}
+		res = append(res, s)
+	}
+	return strings.Join(res, ", ")
+}
+
+// inspectHistory returns a contract for test.Front/Get whose postcondition
+// passes the call history of the served request to fn. The history is only
+// available while the postconditions are checked.
+func inspectHistory(fn func(calls RPCCallHistory)) *ServiceContract {
+	return &ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName: "Get",
+			PostConditions: []Condition{func(resp *wrapperspb.StringValue, respErr error, req *wrapperspb.StringValue, calls RPCCallHistory) error {
+				fn(calls)
+				return nil
+			}},
+		}},
+	}
+}
+
+func TestTree(t *testing.T) {
+	// readAfterLookup makes a Read call from within each Lookup call.
+	readAfterLookup := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if method == lookupMethod {
+			if _, err := call(ctx, cc, readMethod, "nested"); err != nil {
+				return err
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	tests := []struct {
+		name     string
+		nested   bool
+		served   bool
+		wantTree string
+		wantAll  int
+	}{
+		{name: "flat", wantTree: "Lookup, Read", wantAll: 2},
+		{name: "nested in interceptor", nested: true, wantTree: "Lookup(Read), Read", wantAll: 3},
+		{name: "nested in served call", served: true, wantTree: "Lookup(Read), Read", wantAll: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tree string
+			var all int
+			sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+				tree, all = dumpTree(calls.Tree()), calls.All().Count()
+				for _, call := range calls.All() {
+					for _, child := range call.Children {
+						if child.Parent != call {
+							t.Errorf("parent of %s is not %s", child.FullMethod, call.FullMethod)
+						}
+					}
+				}
+			})}, WithCollector(new(reports)))
+
+			var opts []grpc.DialOption
+			if tt.nested {
+				opts = append(opts, grpc.WithChainUnaryInterceptor(readAfterLookup))
+			}
+			var backCC grpc.ClientConnInterface = serveBack(t, sc, nil, opts...)
+			if tt.served {
+				// The served Lookup is checked by sc as well, so its Read call is
+				// nested in the Lookup call of the front service.
+				servedCC := testservice.ServeMethods(t, "test.Lookup", map[string]testservice.Handler{
+					"Lookup": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+						_, err := call(ctx, backCC, readMethod, in.Value)
+						return in, err
+					},
+				}, []grpc.ServerOption{grpc.UnaryInterceptor(sc.UnaryServerInterceptor())},
+					grpc.WithUnaryInterceptor(sc.UnaryClientInterceptor()))
+				backCC = &lookupRedirect{ClientConnInterface: backCC, lookup: servedCC}
+			}
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				if _, err := call(ctx, backCC, lookupMethod, in.Value); err != nil {
+					return nil, err
+				}
+				_, err := call(ctx, backCC, readMethod, in.Value)
+				return in, err
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+
+			if tree != tt.wantTree {
+				t.Errorf("Tree() = %s, want %s", tree, tt.wantTree)
+			}
+			if all != tt.wantAll {
+				t.Errorf("All() has %d calls, want %d", all, tt.wantAll)
+			}
+		})
+	}
+}
+
+// lookupRedirect sends the Lookup calls to another connection.
+type lookupRedirect struct {
+	grpc.ClientConnInterface
+	lookup grpc.ClientConnInterface
+}
+
+func (r *lookupRedirect) Invoke(ctx context.Context, method string, args, reply interface{}, opts ...grpc.CallOption) error {
+	if method == lookupMethod {
+		method = "/test.Lookup/Lookup"
+		return r.lookup.Invoke(ctx, method, args, reply, opts...)
+	}
+	return r.ClientConnInterface.Invoke(ctx, method, args, reply, opts...)
+}
+
+func TestHistoryHasCompletedCallsOnly(t *testing.T) {
+	sc, _ := newServerContract(t, nil)
+	var inFlight int
+	peek := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		requestID, _ := ctx.Value(RequestIDKey).(string)
+		h := RPCCallHistory{requestID: requestID, sc: sc}
+		inFlight = h.All().Count()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	backCC := serveBack(t, sc, nil, grpc.WithChainUnaryInterceptor(peek))
+	var completed int
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		_, err := call(ctx, backCC, lookupMethod, in.Value)
+		requestID, _ := ctx.Value(RequestIDKey).(string)
+		h := RPCCallHistory{requestID: requestID, sc: sc}
+		completed = h.All().Count()
+		return in, err
+	})
+	if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+		t.Fatal(err)
+	}
+	if inFlight != 0 || completed != 1 {
+		t.Errorf("history has %d calls during the call and %d after it, want 0 and 1", inFlight, completed)
+	}
+}
+
+func TestWithParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		parent   func(lookup *UnaryRPCCall) string
+		async    bool
+		wantTree string
+	}{
+		{name: "completed parent", parent: func(lookup *UnaryRPCCall) string { return lookup.ID }, wantTree: "Lookup(Read)"},
+		{name: "goroutine", parent: func(lookup *UnaryRPCCall) string { return lookup.ID }, async: true, wantTree: "Lookup(Read)"},
+		{name: "unknown parent", parent: func(*UnaryRPCCall) string { return "unknown" }, wantTree: "Lookup, Read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tree string
+			sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+				tree = dumpTree(calls.Tree())
+			})})
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				var lookup *UnaryRPCCall
+				if _, err := testservice.Invoke(ctx, backCC, lookupMethod, in.Value, CaptureCall(&lookup)); err != nil {
+					return nil, err
+				}
+				if lookup == nil || lookup.FullMethod != lookupMethod {
+					return nil, fmt.Errorf("captured call %v, want the Lookup call", lookup)
+				}
+				read := func() error {
+					_, err := call(WithParent(ctx, tt.parent(lookup)), backCC, readMethod, in.Value)
+					return err
+				}
+				if !tt.async {
+					return in, read()
+				}
+				errc := make(chan error)
+				go func() { errc <- read() }()
+				return in, <-errc
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+			if tree != tt.wantTree {
+				t.Errorf("Tree() = %s, want %s", tree, tt.wantTree)
+			}
+		})
+	}
+}
+
+func TestCallFromContext(t *testing.T) {
+	sc, _ := newServerContract(t, nil)
+	var current *UnaryRPCCall
+	peek := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		current, _ = CallFromContext(ctx)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	backCC := serveBack(t, sc, nil, grpc.WithChainUnaryInterceptor(peek))
+	var captured *UnaryRPCCall
+	var handlerCall bool
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		_, handlerCall = CallFromContext(ctx)
+		_, err := testservice.Invoke(ctx, backCC, lookupMethod, in.Value, CaptureCall(&captured))
+		return in, err
+	})
+	if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+		t.Fatal(err)
+	}
+	if handlerCall {
+		t.Error("CallFromContext() found a call in the context of the handler")
+	}
+	if current == nil || current != captured {
+		t.Errorf("CallFromContext() = %v, want the captured call %v", current, captured)
+	}
+
+	// Calls made without the context of a served request are not recorded.
+	captured = nil
+	if _, err := testservice.Invoke(context.Background(), backCC, lookupMethod, "note", CaptureCall(&captured)); err != nil {
+		t.Fatal(err)
+	}
+	if captured != nil || current != nil {
+		t.Errorf("captured %v and %v, want no call", captured, current)
+	}
+}
+
+func TestCallTiming(t *testing.T) {
+	const delay = 10 * time.Millisecond
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{name: "deadline", timeout: time.Minute},
+		{name: "no deadline"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup *UnaryRPCCall
+			sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+				lookup, _ = calls.All().First()
+			})})
+			backCC := serveBack(t, sc, map[string]testservice.Handler{
+				"Lookup": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+					time.Sleep(delay)
+					return in, nil
+				},
+			})
+			var start time.Time
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				start = time.Now()
+				if tt.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+					defer cancel()
+				}
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+
+			if lookup == nil {
+				t.Fatal("no call recorded")
+			}
+			if lookup.StartTime.Before(start) || lookup.Duration < delay || lookup.EndTime.Sub(lookup.StartTime) != lookup.Duration {
+				t.Errorf("call started at %v and took %s until %v, want it to start after %v and take at least %s",
+					lookup.StartTime, lookup.Duration, lookup.EndTime, start, delay)
+			}
+			if tt.timeout == 0 && !lookup.Deadline.IsZero() {
+				t.Errorf("deadline = %v, want none", lookup.Deadline)
+			}
+			if tt.timeout > 0 && (lookup.Deadline.Before(start.Add(tt.timeout)) || lookup.Deadline.After(lookup.StartTime.Add(tt.timeout))) {
+				t.Errorf("deadline = %v, want %s after the start of the call", lookup.Deadline, tt.timeout)
+			}
+			if lookup.Attempts != 1 {
+				t.Errorf("attempts = %d, want 1", lookup.Attempts)
+			}
+		})
+	}
+}
+
+func TestCallMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "success"},
+		{name: "failure", err: status.Error(codes.NotFound, "no such token")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup *UnaryRPCCall
+			sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+				lookup, _ = calls.All().First()
+			})}, WithCollector(new(reports)))
+			backCC := serveBack(t, sc, map[string]testservice.Handler{
+				"Lookup": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+					grpc.SetHeader(ctx, metadata.Pairs("x-header", "h"))
+					grpc.SetTrailer(ctx, metadata.Pairs("x-trailer", "t"))
+					return in, tt.err
+				},
+			})
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				ctx = metadata.AppendToOutgoingContext(ctx, "x-user", "alice")
+				call(ctx, backCC, lookupMethod, in.Value)
+				return in, nil
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+
+			if lookup == nil {
+				t.Fatal("no call recorded")
+			}
+			if got := lookup.RequestMetadata.Get("x-user"); len(got) != 1 || got[0] != "alice" {
+				t.Errorf("request metadata x-user = %v, want [alice]", got)
+			}
+			if got := lookup.RequestMetadata.Get(traceIDHeader); len(got) > 0 {
+				t.Errorf("request metadata has trace header %v", got)
+			}
+			if got := lookup.Header.Get("x-header"); len(got) != 1 || got[0] != "h" {
+				t.Errorf("header x-header = %v, want [h]", got)
+			}
+			if got := lookup.Trailer.Get("x-trailer"); len(got) != 1 || got[0] != "t" {
+				t.Errorf("trailer x-trailer = %v, want [t]", got)
+			}
+			if status.Code(lookup.Error) != status.Code(tt.err) {
+				t.Errorf("error = %v, want %v", lookup.Error, tt.err)
+			}
+		})
+	}
+}
+
+// queryCalls returns calls to test.Front and test.Back with different
+// outcomes, in invocation order.
+func queryCalls() CallSet {
+	return CallSet{
+		{FullMethod: lookupMethod, Order: 0, Request: str("a"), Response: str("A")},
+		{FullMethod: readMethod, Order: 1, Request: str("b"), Error: status.Error(codes.NotFound, "b")},
+		{FullMethod: getMethod, Order: 2, Request: wrapperspb.Int64(3), Response: wrapperspb.Int64(3)},
+		{FullMethod: readMethod, Order: 3, Request: str("c"), Error: status.Error(codes.Unavailable, "c")},
+		{FullMethod: lookupMethod, Order: 4, Request: str("d"), Response: str("D")},
+	}
+}
+
+func orders(cs CallSet) []int {
+	var res []int
+	for _, call := range cs {
+		res = append(res, call.Order)
+	}
+	return res
+}
+
+func TestCallSetFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter func(CallSet) CallSet
+		want   []int
+	}{
+		{"successful", CallSet.Successful, []int{0, 2, 4}},
+		{"failed", CallSet.Failed, []int{1, 3}},
+		{"with code ok", func(cs CallSet) CallSet { return cs.WithCode(codes.OK) }, []int{0, 2, 4}},
+		{"with code", func(cs CallSet) CallSet { return cs.WithCode(codes.NotFound) }, []int{1}},
+		{"with missing code", func(cs CallSet) CallSet { return cs.WithCode(codes.Internal) }, nil},
+		{"service", func(cs CallSet) CallSet { return cs.FilterService(backService) }, []int{0, 1, 3, 4}},
+		{"service prefix", func(cs CallSet) CallSet { return cs.FilterService("test.B") }, nil},
+		{"where", func(cs CallSet) CallSet {
+			return cs.Where(func(call *UnaryRPCCall) bool { return call.Order%2 == 0 })
+		}, []int{0, 2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orders(tt.filter(queryCalls())); !slices.Equal(got, tt.want) {
+				t.Errorf("got calls %v, want %v", got, tt.want)
+			}
+			if got := tt.filter(nil); !got.Empty() {
+				t.Errorf("filtering an empty call set returned %d calls", got.Count())
+			}
+		})
+	}
+}
+
+func TestCallSetPredicates(t *testing.T) {
+	failed := func(call *UnaryRPCCall) bool { return call.Error != nil }
+	back := func(call *UnaryRPCCall) bool { return strings.HasPrefix(call.FullMethod, "/"+backService+"/") }
+	tests := []struct {
+		name    string
+		cs      CallSet
+		pred    func(*UnaryRPCCall) bool
+		wantAny bool
+		wantAll bool
+	}{
+		{"some", queryCalls(), failed, true, false},
+		{"all", queryCalls().FilterService(backService), back, true, true},
+		{"none", queryCalls().Successful(), failed, false, false},
+		{"empty", nil, failed, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cs.Any(tt.pred); got != tt.wantAny {
+				t.Errorf("Any() = %v, want %v", got, tt.wantAny)
+			}
+			if got := tt.cs.All(tt.pred); got != tt.wantAll {
+				t.Errorf("All() = %v, want %v", got, tt.wantAll)
+			}
+		})
+	}
+}
+
+func TestCallSetMethods(t *testing.T) {
+	want := []string{lookupMethod, readMethod, getMethod}
+	if got := queryCalls().Methods(); !slices.Equal(got, want) {
+		t.Errorf("Methods() = %v, want %v", got, want)
+	}
+	if got := CallSet(nil).Methods(); got != nil {
+		t.Errorf("Methods() of empty call set = %v, want nil", got)
+	}
+}
+
+func TestCallSetBodies(t *testing.T) {
+	cs := queryCalls()
+	var reqs []string
+	for _, req := range Requests[*wrapperspb.StringValue](cs) {
+		reqs = append(reqs, req.Value)
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(reqs, want) {
+		t.Errorf("Requests() = %v, want %v", reqs, want)
+	}
+
+	var resps []string
+	for _, resp := range Responses[*wrapperspb.StringValue](cs) {
+		resps = append(resps, resp.Value)
+	}
+	if want := []string{"A", "D"}; !slices.Equal(resps, want) {
+		t.Errorf("Responses() = %v, want %v", resps, want)
+	}
+
+	if got := Responses[*wrapperspb.Int64Value](cs); len(got) != 1 || got[0].Value != 3 {
+		t.Errorf("Responses() = %v, want [3]", got)
+	}
+	if got := Requests[*wrapperspb.BoolValue](cs); got != nil {
+		t.Errorf("Requests() = %v, want nil", got)
+	}
+}
+
+func TestCallSetOrdered(t *testing.T) {
+	cs := CallSet{{Order: 2}, {Order: 0}, {Order: 1}}
+	if got := orders(cs.Ordered()); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Ordered() = %v, want [0 1 2]", got)
+	}
+	if got := orders(cs); !slices.Equal(got, []int{2, 0, 1}) {
+		t.Errorf("Ordered() modified the call set to %v", got)
+	}
+}
+
+func TestCallSetAccessors(t *testing.T) {
+	cs := queryCalls()
+	tests := []struct {
+		name    string
+		get     func(CallSet) (*UnaryRPCCall, error)
+		want    int
+		wantErr bool
+	}{
+		{name: "first", get: CallSet.First, want: 0},
+		{name: "last", get: CallSet.Last, want: 4},
+		{name: "nth", get: func(cs CallSet) (*UnaryRPCCall, error) { return cs.Nth(3) }, want: 3},
+		{name: "nth past the end", get: func(cs CallSet) (*UnaryRPCCall, error) { return cs.Nth(5) }, wantErr: true},
+		{name: "negative nth", get: func(cs CallSet) (*UnaryRPCCall, error) { return cs.Nth(-1) }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			call, err := tt.get(cs)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNoCall) {
+					t.Errorf("got error %v, want ErrNoCall", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if call.Order != tt.want {
+				t.Errorf("got call #%d, want #%d", call.Order, tt.want)
+			}
+			if _, err := tt.get(nil); !errors.Is(err, ErrNoCall) {
+				t.Errorf("got error %v on empty call set, want ErrNoCall", err)
+			}
+		})
+	}
+}
+
+func TestHistoryOrder(t *testing.T) {
+	methods := []string{lookupMethod, readMethod, readMethod, lookupMethod, readMethod, lookupMethod}
+	var all, lookups, back []int
+	var zero CallSet
+	sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+		all = orders(calls.All())
+		lookups = orders(calls.Filter(backService, "Lookup"))
+		back = orders(calls.FilterService(backService))
+		zero = new(RPCCallHistory).All()
+	})})
+	backCC := serveBack(t, sc, nil)
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		for _, method := range methods {
+			if _, err := call(ctx, backCC, method, in.Value); err != nil {
+				return nil, err
+			}
+		}
+		return in, nil
+	})
+	if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 2, 3, 4, 5}; !slices.Equal(all, want) || !slices.Equal(back, want) {
+		t.Errorf("All() = %v and FilterService() = %v, want %v", all, back, want)
+	}
+	if want := []int{0, 3, 5}; !slices.Equal(lookups, want) {
+		t.Errorf("Filter() = %v, want %v", lookups, want)
+	}
+	if zero != nil {
+		t.Errorf("zero history has calls %v", zero)
+	}
+}
@@ -0,0 +1,134 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ClientContract is a contract defined for the RPCs called by a gRPC client.
+// It checks the contracts of remote methods on the client side, without
+// requiring the server to be monitored.
+//
+// Only PreConditions, PostConditions, AllowedCodes and StatusConditions of
+// UnaryRPCContracts are checked by a ClientContract, so the same contracts can
+// be registered to a ServerContract and a ClientContract. The client does not
+// see the downstream calls made by the server, so postconditions are given
+// the zero RPCCallHistory, which has no calls. Violations are logged, reported
+// and handled according to the violation policy of the client contract, like
+// the violations of a ServerContract.
+type ClientContract struct {
+	logFunc   LogFunc
+	reporters reporters
+	policy    int32
+
+	contractsLock     sync.RWMutex
+	unaryRPCContracts map[string]*UnaryRPCContract
+}
+
+// NewClientContract creates a ClientContract that has no contracts registered.
+// It requires a logger function to log the violation of its contracts.
+func NewClientContract(logFunc LogFunc) *ClientContract {
+	return &ClientContract{
+		logFunc:           logFunc,
+		unaryRPCContracts: make(map[string]*UnaryRPCContract),
+	}
+}
+
+// RegisterServiceContract registers the contract of a remote service and its
+// RPC contracts to the client contract.
+func (cc *ClientContract) RegisterServiceContract(svcContract *ServiceContract) error {
+	if err := svcContract.validate(); err != nil {
+		return err
+	}
+
+	cc.contractsLock.Lock()
+	defer cc.contractsLock.Unlock()
+
+	for _, rpcContract := range svcContract.RPCContracts {
+		fullMethodName := getFullMethodName(svcContract.ServiceName, rpcContract.MethodName)
+		if _, ok := cc.unaryRPCContracts[fullMethodName]; ok {
+			return errors.New("ClientContract.RegisterServiceContract found duplicate contract registration")
+		}
+	}
+	for _, rpcContract := range svcContract.RPCContracts {
+		cc.unaryRPCContracts[getFullMethodName(svcContract.ServiceName, rpcContract.MethodName)] = rpcContract
+	}
+	return nil
+}
+
+// AddReporter adds a reporter to the client contract. It returns a function
+// that removes the reporter.
+func (cc *ClientContract) AddReporter(r Reporter) (remove func()) {
+	return cc.reporters.add(r)
+}
+
+// ViolationPolicy returns the current violation policy of the client contract.
+func (cc *ClientContract) ViolationPolicy() ViolationPolicy {
+	return ViolationPolicy(atomic.LoadInt32(&cc.policy))
+}
+
+// SetViolationPolicy changes the violation policy of the client contract. It
+// takes effect for the calls made afterwards.
+func (cc *ClientContract) SetViolationPolicy(p ViolationPolicy) {
+	atomic.StoreInt32(&cc.policy, int32(p))
+}
+
+// report logs a violation of a called RPC and notifies the reporters of cc.
+func (cc *ClientContract) report(v *Violation) {
+	cc.reporters.report(cc.logFunc, v)
+}
+
+// UnaryClientInterceptor returns a new unary client interceptor for
+// monitoring the contracts of the called RPCs.
+func (cc *ClientContract) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, conn *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		policy := cc.ViolationPolicy()
+		cc.contractsLock.RLock()
+		c, ok := cc.unaryRPCContracts[method]
+		cc.contractsLock.RUnlock()
+		if !ok || policy == IgnoreViolations {
+			return invoker(ctx, method, req, reply, conn, opts...)
+		}
+
+		var violation *Violation
+		report := func(v *Violation) {
+			cc.report(v)
+			if violation == nil {
+				violation = v
+			}
+		}
+		for _, preCondition := range c.PreConditions {
+			if err := invokePreCondition(preCondition, req); err != nil {
+				report(&Violation{Name: preConditionViolation, Call: method, Err: err, Request: req})
+			}
+		}
+		if violation != nil && policy == RejectViolations {
+			return status.Error(codes.InvalidArgument, violation.Error())
+		}
+
+		err := invoker(ctx, method, req, reply, conn, opts...)
+
+		resp := reply
+		if err != nil {
+			resp = nil
+		}
+		for _, postCondition := range c.PostConditions {
+			if condErr := invokePostCondition(postCondition, resp, err, req, RPCCallHistory{}); condErr != nil {
+				report(&Violation{Name: postConditionViolation, Call: method, Err: condErr, Request: req, Response: resp, ResponseError: err, responded: true})
+			}
+		}
+		for _, statusErr := range c.checkStatus(err) {
+			report(&Violation{Name: statusViolation, Call: method, Err: statusErr, Request: req, Response: resp, ResponseError: err, responded: true})
+		}
+		if violation != nil && policy == RejectViolations {
+			return status.Error(codes.Internal, violation.Error())
+		}
+		return err
+	}
+}
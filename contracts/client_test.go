@@ -0,0 +1,281 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// logs is a LogFunc that keeps the logged violations.
+type logs struct {
+	mu   sync.Mutex
+	errs []string
+}
+
+func (l *logs) log(args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errs = append(l.errs, fmt.Sprint(args[0]))
+}
+
+func (l *logs) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.errs
+}
+
+func TestClientContract(t *testing.T) {
+	var gotResp *wrapperspb.StringValue
+	svcContract := &ServiceContract{
+		ServiceName: backService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName: "Lookup",
+			PreConditions: []Condition{func(req *wrapperspb.StringValue) error {
+				if req.Value == "" {
+					return errors.New("empty request")
+				}
+				return nil
+			}},
+			PostConditions: []Condition{func(resp *wrapperspb.StringValue, respErr error, req *wrapperspb.StringValue, calls RPCCallHistory) error {
+				gotResp = resp
+				if len(calls.All()) > 0 {
+					return errors.New("client call has downstream calls")
+				}
+				if respErr == nil && resp.Value != req.Value {
+					return errors.New("response differs from request")
+				}
+				return nil
+			}},
+			AllowedCodes: []codes.Code{codes.NotFound},
+		}},
+	}
+	lookup := func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		switch in.Value {
+		case "missing":
+			return nil, status.Error(codes.NotFound, "not found")
+		case "broken":
+			return nil, status.Error(codes.Internal, "broken")
+		case "other":
+			return str("something else"), nil
+		}
+		return in, nil
+	}
+	tests := []struct {
+		name     string
+		method   string
+		value    string
+		want     []string
+		wantResp bool
+	}{
+		{name: "valid", method: lookupMethod, value: "note", wantResp: true},
+		{name: "precondition violated", method: lookupMethod, want: []string{"empty request"}, wantResp: true},
+		{name: "postcondition violated", method: lookupMethod, value: "other", want: []string{"response differs from request"}, wantResp: true},
+		{name: "allowed error", method: lookupMethod, value: "missing"},
+		{name: "disallowed error", method: lookupMethod, value: "broken", want: []string{"status code Internal is not allowed, allowed codes are [NotFound]"}},
+		{name: "no contract", method: readMethod, value: "broken"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := new(logs)
+			cc := NewClientContract(l.log)
+			if err := cc.RegisterServiceContract(svcContract); err != nil {
+				t.Fatal(err)
+			}
+			conn := testservice.ServeMethods(t, backService, map[string]testservice.Handler{"Lookup": lookup, "Read": lookup},
+				nil, grpc.WithUnaryInterceptor(cc.UnaryClientInterceptor()))
+
+			gotResp = nil
+			call(context.Background(), conn, tt.method, tt.value)
+			if got := l.get(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+			if tt.method == lookupMethod && (gotResp != nil) != tt.wantResp {
+				t.Errorf("postcondition got response %v, want response %v", gotResp, tt.wantResp)
+			}
+		})
+	}
+}
+
+func TestClientContractRegistration(t *testing.T) {
+	tests := []struct {
+		name        string
+		svcContract *ServiceContract
+	}{
+		{
+			name: "postcondition without call history",
+			svcContract: &ServiceContract{
+				ServiceName: frontService,
+				RPCContracts: []*UnaryRPCContract{{
+					MethodName: "Get",
+					PostConditions: []Condition{func(resp *wrapperspb.StringValue, respErr error, req *wrapperspb.StringValue) error {
+						return nil
+					}},
+				}},
+			},
+		},
+		{
+			name: "nil service contract",
+		},
+		{
+			name:        "nil rpc contract",
+			svcContract: &ServiceContract{ServiceName: frontService, RPCContracts: []*UnaryRPCContract{nil}},
+		},
+		{
+			name: "invalid precondition",
+			svcContract: &ServiceContract{
+				ServiceName:  frontService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Get", PreConditions: []Condition{"not a function"}}},
+			},
+		},
+		{
+			name: "nil status condition",
+			svcContract: &ServiceContract{
+				ServiceName:  frontService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Get", StatusConditions: []StatusCondition{nil}}},
+			},
+		},
+		{
+			name: "duplicate",
+			svcContract: &ServiceContract{
+				ServiceName:  backService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cc := NewClientContract(t.Log)
+			if err := cc.RegisterServiceContract(&ServiceContract{
+				ServiceName:  backService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup"}},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if err := cc.RegisterServiceContract(tt.svcContract); err == nil {
+				t.Error("RegisterServiceContract accepted an invalid contract")
+			}
+		})
+	}
+}
+
+func TestClientContractReporter(t *testing.T) {
+	cc := NewClientContract(t.Log)
+	if err := cc.RegisterServiceContract(&ServiceContract{
+		ServiceName:  backService,
+		RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup", AllowedCodes: []codes.Code{}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var got []*Violation
+	remove := cc.AddReporter(ReporterFunc(func(v *Violation) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, v)
+	}))
+	lookup := func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		return nil, status.Error(codes.Internal, "broken")
+	}
+	conn := testservice.ServeMethods(t, backService, map[string]testservice.Handler{"Lookup": lookup},
+		nil, grpc.WithUnaryInterceptor(cc.UnaryClientInterceptor()))
+
+	call(context.Background(), conn, lookupMethod, "note")
+	remove()
+	call(context.Background(), conn, lookupMethod, "note")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 {
+		t.Fatalf("reported %v, want 1 violation", got)
+	}
+	if v := got[0]; v.Call != lookupMethod || v.FullMethod != "" || status.Code(v.ResponseError) != codes.Internal {
+		t.Errorf("reported %+v, want the violation of the call to %s", v, lookupMethod)
+	}
+}
+
+func TestClientContractViolationPolicy(t *testing.T) {
+	cc := NewClientContract(t.Log)
+	if err := cc.RegisterServiceContract(&ServiceContract{
+		ServiceName: backService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName: "Lookup",
+			PreConditions: []Condition{func(req *wrapperspb.StringValue) error {
+				if req.Value == "" {
+					return errors.New("empty request")
+				}
+				return nil
+			}},
+			AllowedCodes: []codes.Code{},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	var violations int
+	cc.AddReporter(ReporterFunc(func(*Violation) {
+		mu.Lock()
+		defer mu.Unlock()
+		violations++
+	}))
+	var called int
+	lookup := func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		called++
+		if in.Value == "broken" {
+			return nil, status.Error(codes.Unavailable, "broken")
+		}
+		return in, nil
+	}
+	conn := testservice.ServeMethods(t, backService, map[string]testservice.Handler{"Lookup": lookup},
+		nil, grpc.WithUnaryInterceptor(cc.UnaryClientInterceptor()))
+
+	tests := []struct {
+		name           string
+		policy         ViolationPolicy
+		value          string
+		wantCode       codes.Code
+		wantCalled     bool
+		wantViolations int
+	}{
+		{name: "log precondition", policy: LogViolations, wantCalled: true, wantViolations: 1},
+		{name: "log status", policy: LogViolations, value: "broken", wantCode: codes.Unavailable, wantCalled: true, wantViolations: 1},
+		{name: "reject valid", policy: RejectViolations, value: "note", wantCalled: true},
+		{name: "reject precondition", policy: RejectViolations, wantCode: codes.InvalidArgument, wantViolations: 1},
+		{name: "reject status", policy: RejectViolations, value: "broken", wantCode: codes.Internal, wantCalled: true, wantViolations: 1},
+		{name: "ignore", policy: IgnoreViolations, value: "broken", wantCode: codes.Unavailable, wantCalled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			violations, called = 0, 0
+			mu.Unlock()
+			cc.SetViolationPolicy(tt.policy)
+			if got := cc.ViolationPolicy(); got != tt.policy {
+				t.Fatalf("ViolationPolicy() = %s, want %s", got, tt.policy)
+			}
+
+			_, err := call(context.Background(), conn, lookupMethod, tt.value)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("call returned %v, want code %s", err, tt.wantCode)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if (called > 0) != tt.wantCalled {
+				t.Errorf("called = %v, want %v", called > 0, tt.wantCalled)
+			}
+			if violations != tt.wantViolations {
+				t.Errorf("got %d violations, want %d", violations, tt.wantViolations)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package contracts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Collector gathers the RPC calls recorded by ServerContracts of different
+// services, so that contracts can be checked over the whole call tree of a
+// request. See package collector for implementations.
+type Collector interface {
+	// Report delivers the calls recorded during a single server request.
+	Report(ctx context.Context, report *CallReport) error
+}
+
+// CallReport is the set of RPC calls recorded by a ServerContract while
+// serving a request.
+type CallReport struct {
+	// TraceID identifies the tree of calls that the report belongs to. It is
+	// shared by all of the services that take part in serving a root request.
+	TraceID string
+	// Call is the RPC served by the reporting ServerContract. Its ID is the ID
+	// of the downstream call that caused the request, if any, and its
+	// RequestMetadata is the incoming metadata of the request.
+	Call *UnaryRPCCall
+	// Calls are the downstream RPC calls made while serving Call, in invocation order.
+	Calls CallSet
+	// Root is true if Call is the root of the call tree, i.e., it is not made by
+	// a service that reports to the collector.
+	Root bool
+}
+
+const (
+	// DefaultReportQueueSize is the default number of reports that may wait
+	// for delivery to the collector.
+	DefaultReportQueueSize = 1024
+	// DefaultReportTimeout is the default time that delivering a report to
+	// the collector may take.
+	DefaultReportTimeout = 5 * time.Second
+)
+
+func (sc *ServerContract) collect(s span, call *UnaryRPCCall, requestID string) {
+	history := RPCCallHistory{requestID: requestID, sc: sc}
+	report := &CallReport{
+		TraceID: s.traceID,
+		Call:    call,
+		Calls:   history.All(),
+		Root:    s.root,
+	}
+	sc.reports.push(sc, report)
+}
+
+// reportQueue delivers the reports of a ServerContract to its collector in
+// the background, in the order they are pushed. The goroutine delivering the
+// reports only runs while the queue is not empty.
+type reportQueue struct {
+	size    int
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending []*CallReport
+	idle    chan struct{}
+}
+
+func (q *reportQueue) push(sc *ServerContract, report *CallReport) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.pending) >= q.size {
+		sc.logFunc("report queue of collector is full, dropping the report of", report.Call.FullMethod)
+		return
+	}
+	q.pending = append(q.pending, report)
+	if q.idle == nil {
+		q.idle = make(chan struct{})
+		go q.deliver(sc)
+	}
+}
+
+func (q *reportQueue) deliver(sc *ServerContract) {
+	for {
+		q.mu.Lock()
+		if len(q.pending) == 0 {
+			close(q.idle)
+			q.idle = nil
+			q.mu.Unlock()
+			return
+		}
+		report := q.pending[0]
+		q.pending[0] = nil
+		q.pending = q.pending[1:]
+		q.mu.Unlock()
+
+		// The request context is not used here, so that reporting does not get
+		// canceled along with the request or recorded by UnaryClientInterceptor.
+		ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+		if err := sc.collector.Report(ctx, report); err != nil {
+			sc.logFunc("reporting calls to collector failed:", err, report.Call.FullMethod)
+		}
+		cancel()
+	}
+}
+
+// wait blocks until the queue is empty or ctx is done.
+func (q *reportQueue) wait(ctx context.Context) error {
+	q.mu.Lock()
+	idle := q.idle
+	q.mu.Unlock()
+	if idle == nil {
+		return nil
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FlushReports blocks until the reports of the requests served so far are
+// delivered to the collector, or ctx is done. It is useful before shutting
+// down, as reports are delivered in the background.
+func (sc *ServerContract) FlushReports(ctx context.Context) error {
+	return sc.reports.wait(ctx)
+}
@@ -0,0 +1,193 @@
+// Package collector provides a Collector that rebuilds the call tree of
+// requests spanning multiple services and checks contracts over them.
+//
+// ServerContracts report the calls they record to a collector using the
+// contracts.WithCollector option. Services running in other processes can
+// report to a collector through the Collector gRPC service, see NewServer and
+// NewClient.
+package collector
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+)
+
+// DefaultTraceTimeout is the default time the collector waits for the root
+// call of a trace to finish before dropping the trace.
+const DefaultTraceTimeout = time.Minute
+
+// Condition is a condition over the call tree of a request. root is the node
+// of the root RPC.
+type Condition func(root *Node) error
+
+// Contract is a contract checked over the whole call tree of a request once
+// its root RPC finishes.
+type Contract struct {
+	// RootMethod is the full method of the root RPCs the contract applies to,
+	// i.e., /package.service/method. Empty RootMethod matches all root RPCs.
+	RootMethod string
+	// Conditions are conditions that must always be true after the execution
+	// of the root RPC.
+	Conditions []Condition
+}
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithTraceTimeout sets the time the collector waits for the root call of a
+// trace to finish. Traces whose root is never reported, e.g., because the root
+// service does not report to the collector, are dropped after this timeout.
+func WithTraceTimeout(d time.Duration) Option {
+	return func(c *Collector) {
+		c.traceTimeout = d
+	}
+}
+
+type trace struct {
+	id      string
+	nodes   map[string]*Node
+	updated time.Time
+	elem    *list.Element
+}
+
+// Collector is an in-process contracts.Collector.
+type Collector struct {
+	logFunc      contracts.LogFunc
+	traceTimeout time.Duration
+
+	contractsLock sync.RWMutex
+	contracts     []*Contract
+
+	tracesLock sync.Mutex
+	traces     map[string]*trace
+	// byUpdate holds the traces ordered by their last update, least
+	// recently updated first, so that expired traces are found without
+	// scanning all traces.
+	byUpdate *list.List
+}
+
+var _ contracts.Collector = (*Collector)(nil)
+
+// New creates a Collector that has no contracts registered.
+// It requires a logger function to log the violation of its contracts.
+func New(logFunc contracts.LogFunc, opts ...Option) *Collector {
+	c := &Collector{
+		logFunc:      logFunc,
+		traceTimeout: DefaultTraceTimeout,
+		traces:       make(map[string]*trace),
+		byUpdate:     list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// RegisterContract registers a contract to be checked over call trees.
+func (c *Collector) RegisterContract(contract *Contract) error {
+	for _, cond := range contract.Conditions {
+		if cond == nil {
+			return errors.New("Collector.RegisterContract found nil condition")
+		}
+	}
+
+	c.contractsLock.Lock()
+	defer c.contractsLock.Unlock()
+	c.contracts = append(c.contracts, contract)
+	return nil
+}
+
+// Report adds the reported calls to the call tree of their trace. If the
+// report belongs to the root RPC, the contracts are checked over the tree and
+// the trace is dropped.
+func (c *Collector) Report(ctx context.Context, report *contracts.CallReport) error {
+	if report.Call == nil {
+		return errors.New("Collector.Report got report without call")
+	}
+
+	root := c.add(report)
+	if root == nil {
+		return nil
+	}
+
+	c.contractsLock.RLock()
+	defer c.contractsLock.RUnlock()
+	for _, contract := range c.contracts {
+		if contract.RootMethod != "" && contract.RootMethod != root.FullMethod() {
+			continue
+		}
+		for _, cond := range contract.Conditions {
+			if err := cond(root); err != nil {
+				c.logFunc(err, root.FullMethod(), report.TraceID)
+			}
+		}
+	}
+	return nil
+}
+
+// add merges the report into its trace and returns the root node if the trace is complete.
+func (c *Collector) add(report *contracts.CallReport) *Node {
+	c.tracesLock.Lock()
+	defer c.tracesLock.Unlock()
+
+	now := time.Now()
+	for e := c.byUpdate.Front(); e != nil; e = c.byUpdate.Front() {
+		t := e.Value.(*trace)
+		if now.Sub(t.updated) <= c.traceTimeout {
+			break
+		}
+		c.drop(t)
+	}
+
+	t, ok := c.traces[report.TraceID]
+	if !ok {
+		t = &trace{id: report.TraceID, nodes: make(map[string]*Node)}
+		t.elem = c.byUpdate.PushBack(t)
+		c.traces[report.TraceID] = t
+	} else {
+		c.byUpdate.MoveToBack(t.elem)
+	}
+	t.updated = now
+
+	node := t.node(report.Call.ID)
+	if node.Call == nil {
+		node.Call = report.Call
+	}
+	for _, call := range report.Calls {
+		child := t.node(call.ID)
+		if child.Call == nil {
+			child.Call = call
+		}
+		parent := node
+		if call.Parent != nil {
+			parent = t.node(call.Parent.ID)
+		}
+		parent.Children = append(parent.Children, child)
+	}
+
+	if !report.Root {
+		return nil
+	}
+	c.drop(t)
+	return node
+}
+
+// drop removes a trace. c.tracesLock must be held.
+func (c *Collector) drop(t *trace) {
+	delete(c.traces, t.id)
+	c.byUpdate.Remove(t.elem)
+}
+
+func (t *trace) node(id string) *Node {
+	if n, ok := t.nodes[id]; ok {
+		return n
+	}
+	n := &Node{}
+	t.nodes[id] = n
+	return n
+}
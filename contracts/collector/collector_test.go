@@ -0,0 +1,317 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	pb "github.com/shayanh/grpc-go-contracts/contracts/collector/collectorpb"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	getMethod          = "/test.Front/Get"
+	authenticateMethod = "/test.Auth/Authenticate"
+	lookupMethod       = "/test.Token/Lookup"
+)
+
+// logs gathers the messages logged by a collector.
+type logs []string
+
+func (l *logs) log(args ...interface{}) {
+	*l = append(*l, fmt.Sprint(args...))
+}
+
+// collectorFunc is a contracts.Collector that calls the function.
+type collectorFunc func(ctx context.Context, report *contracts.CallReport) error
+
+func (f collectorFunc) Report(ctx context.Context, report *contracts.CallReport) error {
+	return f(ctx, report)
+}
+
+func call(id, fullMethod string) *contracts.UnaryRPCCall {
+	return &contracts.UnaryRPCCall{ID: id, FullMethod: fullMethod}
+}
+
+// dump returns the call tree rooted at n, e.g., "Get(Authenticate(Lookup))".
+func dump(n *Node) string {
+	name := n.FullMethod()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if name == "" {
+		name = "?"
+	}
+	if len(n.Children) == 0 {
+		return name
+	}
+	var children []string
+	for _, c := range n.Children {
+		children = append(children, dump(c))
+	}
+	return name + "(" + strings.Join(children, ", ") + ")"
+}
+
+func TestCollectorTree(t *testing.T) {
+	authReport := &contracts.CallReport{
+		TraceID: "trace",
+		Call:    call("auth", authenticateMethod),
+		Calls:   contracts.CallSet{call("lookup", lookupMethod)},
+	}
+	rootReport := &contracts.CallReport{
+		TraceID: "trace",
+		Call:    call("root", getMethod),
+		Calls:   contracts.CallSet{call("auth", authenticateMethod)},
+		Root:    true,
+	}
+	tests := []struct {
+		name    string
+		reports []*contracts.CallReport
+		want    string
+	}{
+		{
+			name:    "root only",
+			reports: []*contracts.CallReport{rootReport},
+			want:    "Get(Authenticate)",
+		},
+		{
+			name:    "downstream service first",
+			reports: []*contracts.CallReport{authReport, rootReport},
+			want:    "Get(Authenticate(Lookup))",
+		},
+		{
+			name: "other trace",
+			reports: []*contracts.CallReport{
+				{TraceID: "other", Call: authReport.Call, Calls: authReport.Calls},
+				rootReport,
+			},
+			want: "Get(Authenticate)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			c := New(t.Log)
+			c.RegisterContract(&Contract{Conditions: []Condition{func(root *Node) error {
+				got = dump(root)
+				return nil
+			}}})
+			for _, report := range tt.reports {
+				if err := c.Report(context.Background(), report); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got != tt.want {
+				t.Errorf("tree = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectorContracts(t *testing.T) {
+	reports := []*contracts.CallReport{
+		{
+			TraceID: "trace",
+			Call:    call("auth", authenticateMethod),
+			Calls:   contracts.CallSet{call("lookup", lookupMethod)},
+		},
+		{
+			TraceID: "trace",
+			Call:    call("root", getMethod),
+			Calls:   contracts.CallSet{call("auth", authenticateMethod)},
+			Root:    true,
+		},
+	}
+	tests := []struct {
+		name       string
+		rootMethod string
+		path       []string
+		wantLogs   int
+	}{
+		{name: "path exists", path: []string{"test.Front/Get", "test.Auth/Authenticate", "test.Token/Lookup"}},
+		{name: "path does not exist", path: []string{"test.Front/Get", "test.Token/Lookup"}, wantLogs: 1},
+		{name: "other root method", rootMethod: "/test.Front/List", path: []string{"test.Token/Get"}},
+		{name: "matching root method", rootMethod: getMethod, path: []string{"test.Token/Get"}, wantLogs: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l logs
+			c := New(l.log)
+			err := c.RegisterContract(&Contract{RootMethod: tt.rootMethod, Conditions: []Condition{func(root *Node) error {
+				if !root.HasPath(tt.path...) {
+					return errors.New("path not found")
+				}
+				return nil
+			}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, report := range reports {
+				if err := c.Report(context.Background(), report); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(l) != tt.wantLogs {
+				t.Errorf("got logs %q, want %d", l, tt.wantLogs)
+			}
+		})
+	}
+}
+
+func TestCollectorRegisterContract(t *testing.T) {
+	c := New(t.Log)
+	if err := c.RegisterContract(&Contract{Conditions: []Condition{nil}}); err == nil {
+		t.Error("RegisterContract accepted a nil condition")
+	}
+	if err := c.Report(context.Background(), &contracts.CallReport{}); err == nil {
+		t.Error("Report accepted a report without call")
+	}
+}
+
+func TestCollectorTraceTimeout(t *testing.T) {
+	var got string
+	c := New(t.Log, WithTraceTimeout(time.Millisecond))
+	c.RegisterContract(&Contract{Conditions: []Condition{func(root *Node) error {
+		got = dump(root)
+		return nil
+	}}})
+
+	report := func(traceID string, root bool) {
+		t.Helper()
+		served := call("auth", authenticateMethod)
+		if root {
+			served = call("root", getMethod)
+		}
+		err := c.Report(context.Background(), &contracts.CallReport{
+			TraceID: traceID,
+			Call:    served,
+			Calls:   contracts.CallSet{call("lookup", lookupMethod)},
+			Root:    root,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	report("first", false)
+	report("second", false)
+	time.Sleep(10 * time.Millisecond)
+	report("other", false)
+	if len(c.traces) != 1 || c.byUpdate.Len() != 1 {
+		t.Errorf("got %d traces and %d updates, want the expired traces dropped", len(c.traces), c.byUpdate.Len())
+	}
+	report("other", true)
+	if got != "Get(Lookup)" {
+		t.Errorf("tree = %s, want Get(Lookup)", got)
+	}
+	if len(c.traces) != 0 || c.byUpdate.Len() != 0 {
+		t.Errorf("got %d traces and %d updates after the root report, want none", len(c.traces), c.byUpdate.Len())
+	}
+}
+
+func TestNodeFind(t *testing.T) {
+	root := &Node{Call: call("root", getMethod), Children: []*Node{
+		{Call: call("auth", authenticateMethod), Children: []*Node{{Call: call("lookup", lookupMethod)}}},
+		{Call: call("lookup2", lookupMethod)},
+		{},
+	}}
+	tests := []struct {
+		method string
+		want   int
+	}{
+		{method: lookupMethod, want: 2},
+		{method: "test.Token/Lookup", want: 2},
+		{method: getMethod, want: 1},
+		{method: "test.Token/Missing", want: 0},
+		{method: "", want: 0},
+	}
+	for _, tt := range tests {
+		if got := len(root.Find(tt.method)); got != tt.want {
+			t.Errorf("Find(%q) found %d nodes, want %d", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestReportProto(t *testing.T) {
+	report := &contracts.CallReport{
+		TraceID: "trace",
+		Call: &contracts.UnaryRPCCall{
+			ID:         "root",
+			FullMethod: getMethod,
+			Request:    wrapperspb.String("note"),
+			Response:   wrapperspb.String("text"),
+		},
+		Calls: contracts.CallSet{
+			{
+				ID:         "auth",
+				FullMethod: authenticateMethod,
+				Request:    wrapperspb.String("token"),
+				Error:      status.Error(codes.Unauthenticated, "bad token"),
+			},
+			{ID: "lookup", FullMethod: lookupMethod, Request: "not a message", Order: 1},
+		},
+		Root: true,
+	}
+
+	cc := testservice.Serve(t, &pb.Collector_ServiceDesc, NewServer(collectorFunc(func(ctx context.Context, got *contracts.CallReport) error {
+		if got.TraceID != report.TraceID || !got.Root || len(got.Calls) != 2 {
+			t.Fatalf("got report %+v, want %+v", got, report)
+		}
+		for i, want := range append(contracts.CallSet{report.Call}, report.Calls...) {
+			c := got.Call
+			if i > 0 {
+				c = got.Calls[i-1]
+			}
+			if c.ID != want.ID || c.FullMethod != want.FullMethod || c.Order != want.Order {
+				t.Errorf("call %s = %+v, want %+v", want.ID, c, want)
+			}
+			if m, ok := want.Request.(proto.Message); ok && !proto.Equal(c.Request.(proto.Message), m) {
+				t.Errorf("call %s request = %v, want %v", want.ID, c.Request, want.Request)
+			}
+			if status.Code(c.Error) != status.Code(want.Error) {
+				t.Errorf("call %s error = %v, want %v", want.ID, c.Error, want.Error)
+			}
+		}
+		if got.Calls[1].Request != nil {
+			t.Errorf("request that is not a message = %v, want nil", got.Calls[1].Request)
+		}
+		return nil
+	})), nil)
+	if err := NewClient(cc).Report(context.Background(), report); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoteCollector(t *testing.T) {
+	var got string
+	c := New(t.Log)
+	c.RegisterContract(&Contract{Conditions: []Condition{func(root *Node) error {
+		got = dump(root)
+		return nil
+	}}})
+	cc := testservice.Serve(t, &pb.Collector_ServiceDesc, NewServer(c), nil)
+	remote := NewClient(cc)
+
+	err := remote.Report(context.Background(), &contracts.CallReport{
+		TraceID: "trace",
+		Call:    call("root", getMethod),
+		Calls:   contracts.CallSet{call("auth", authenticateMethod)},
+		Root:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Get(Authenticate)" {
+		t.Errorf("tree = %s, want Get(Authenticate)", got)
+	}
+
+	_, err = pb.NewCollectorClient(cc).Report(context.Background(), &pb.ReportRequest{TraceId: "trace"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("report without call failed with %v, want InvalidArgument", err)
+	}
+}
@@ -0,0 +1,441 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contracts/collector/collectorpb/collector.proto
+
+package collectorpb
+
+import (
+	status "google.golang.org/genproto/googleapis/rpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Call struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id identifies the call within its call tree.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// full_method is the full RPC method string, i.e., /package.service/method.
+	FullMethod string         `protobuf:"bytes,2,opt,name=full_method,json=fullMethod,proto3" json:"full_method,omitempty"`
+	Request    *anypb.Any     `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Response   *anypb.Any     `protobuf:"bytes,4,opt,name=response,proto3" json:"response,omitempty"`
+	Status     *status.Status `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// order represents the invocation time of calls in ascending order.
+	Order int32 `protobuf:"varint,6,opt,name=order,proto3" json:"order,omitempty"`
+	// parent_id is the ID of the call that this call is nested in, if any.
+	ParentId  string                 `protobuf:"bytes,7,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// deadline is the deadline of the call, if any.
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// attempts is the number of attempts made to complete the call.
+	Attempts int32 `protobuf:"varint,11,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// request_metadata is the outgoing metadata sent with the request.
+	RequestMetadata []*MetadataEntry `protobuf:"bytes,12,rep,name=request_metadata,json=requestMetadata,proto3" json:"request_metadata,omitempty"`
+	Header          []*MetadataEntry `protobuf:"bytes,13,rep,name=header,proto3" json:"header,omitempty"`
+	Trailer         []*MetadataEntry `protobuf:"bytes,14,rep,name=trailer,proto3" json:"trailer,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Call) Reset() {
+	*x = Call{}
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Call) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Call) ProtoMessage() {}
+
+func (x *Call) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Call.ProtoReflect.Descriptor instead.
+func (*Call) Descriptor() ([]byte, []int) {
+	return file_contracts_collector_collectorpb_collector_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Call) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Call) GetFullMethod() string {
+	if x != nil {
+		return x.FullMethod
+	}
+	return ""
+}
+
+func (x *Call) GetRequest() *anypb.Any {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *Call) GetResponse() *anypb.Any {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *Call) GetStatus() *status.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *Call) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *Call) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Call) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Call) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *Call) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *Call) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Call) GetRequestMetadata() []*MetadataEntry {
+	if x != nil {
+		return x.RequestMetadata
+	}
+	return nil
+}
+
+func (x *Call) GetHeader() []*MetadataEntry {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *Call) GetTrailer() []*MetadataEntry {
+	if x != nil {
+		return x.Trailer
+	}
+	return nil
+}
+
+type MetadataEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetadataEntry) Reset() {
+	*x = MetadataEntry{}
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetadataEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetadataEntry) ProtoMessage() {}
+
+func (x *MetadataEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetadataEntry.ProtoReflect.Descriptor instead.
+func (*MetadataEntry) Descriptor() ([]byte, []int) {
+	return file_contracts_collector_collectorpb_collector_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MetadataEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MetadataEntry) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ReportRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TraceId string                 `protobuf:"bytes,1,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// call is the RPC served by the reporting service.
+	Call *Call `protobuf:"bytes,2,opt,name=call,proto3" json:"call,omitempty"`
+	// calls are the downstream calls made while serving call.
+	Calls []*Call `protobuf:"bytes,3,rep,name=calls,proto3" json:"calls,omitempty"`
+	// root is true if call is the root of the call tree.
+	Root          bool `protobuf:"varint,4,opt,name=root,proto3" json:"root,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportRequest) Reset() {
+	*x = ReportRequest{}
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportRequest) ProtoMessage() {}
+
+func (x *ReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportRequest.ProtoReflect.Descriptor instead.
+func (*ReportRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_collector_collectorpb_collector_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReportRequest) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *ReportRequest) GetCall() *Call {
+	if x != nil {
+		return x.Call
+	}
+	return nil
+}
+
+func (x *ReportRequest) GetCalls() []*Call {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *ReportRequest) GetRoot() bool {
+	if x != nil {
+		return x.Root
+	}
+	return false
+}
+
+type ReportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportResponse) Reset() {
+	*x = ReportResponse{}
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportResponse) ProtoMessage() {}
+
+func (x *ReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_collector_collectorpb_collector_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportResponse.ProtoReflect.Descriptor instead.
+func (*ReportResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_collector_collectorpb_collector_proto_rawDescGZIP(), []int{3}
+}
+
+var File_contracts_collector_collectorpb_collector_proto protoreflect.FileDescriptor
+
+const file_contracts_collector_collectorpb_collector_proto_rawDesc = "" +
+	"\n" +
+	"/contracts/collector/collectorpb/collector.proto\x12\x13contracts.collector\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17google/rpc/status.proto\"\x87\x05\n" +
+	"\x04Call\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vfull_method\x18\x02 \x01(\tR\n" +
+	"fullMethod\x12.\n" +
+	"\arequest\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\arequest\x120\n" +
+	"\bresponse\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\bresponse\x12*\n" +
+	"\x06status\x18\x05 \x01(\v2\x12.google.rpc.StatusR\x06status\x12\x14\n" +
+	"\x05order\x18\x06 \x01(\x05R\x05order\x12\x1b\n" +
+	"\tparent_id\x18\a \x01(\tR\bparentId\x129\n" +
+	"\n" +
+	"start_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x126\n" +
+	"\bdeadline\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1a\n" +
+	"\battempts\x18\v \x01(\x05R\battempts\x12M\n" +
+	"\x10request_metadata\x18\f \x03(\v2\".contracts.collector.MetadataEntryR\x0frequestMetadata\x12:\n" +
+	"\x06header\x18\r \x03(\v2\".contracts.collector.MetadataEntryR\x06header\x12<\n" +
+	"\atrailer\x18\x0e \x03(\v2\".contracts.collector.MetadataEntryR\atrailer\"9\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"\x9e\x01\n" +
+	"\rReportRequest\x12\x19\n" +
+	"\btrace_id\x18\x01 \x01(\tR\atraceId\x12-\n" +
+	"\x04call\x18\x02 \x01(\v2\x19.contracts.collector.CallR\x04call\x12/\n" +
+	"\x05calls\x18\x03 \x03(\v2\x19.contracts.collector.CallR\x05calls\x12\x12\n" +
+	"\x04root\x18\x04 \x01(\bR\x04root\"\x10\n" +
+	"\x0eReportResponse2`\n" +
+	"\tCollector\x12S\n" +
+	"\x06Report\x12\".contracts.collector.ReportRequest\x1a#.contracts.collector.ReportResponse\"\x00BFZDgithub.com/shayanh/grpc-go-contracts/contracts/collector/collectorpbb\x06proto3"
+
+var (
+	file_contracts_collector_collectorpb_collector_proto_rawDescOnce sync.Once
+	file_contracts_collector_collectorpb_collector_proto_rawDescData []byte
+)
+
+func file_contracts_collector_collectorpb_collector_proto_rawDescGZIP() []byte {
+	file_contracts_collector_collectorpb_collector_proto_rawDescOnce.Do(func() {
+		file_contracts_collector_collectorpb_collector_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_collector_collectorpb_collector_proto_rawDesc), len(file_contracts_collector_collectorpb_collector_proto_rawDesc)))
+	})
+	return file_contracts_collector_collectorpb_collector_proto_rawDescData
+}
+
+var file_contracts_collector_collectorpb_collector_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_contracts_collector_collectorpb_collector_proto_goTypes = []any{
+	(*Call)(nil),                  // 0: contracts.collector.Call
+	(*MetadataEntry)(nil),         // 1: contracts.collector.MetadataEntry
+	(*ReportRequest)(nil),         // 2: contracts.collector.ReportRequest
+	(*ReportResponse)(nil),        // 3: contracts.collector.ReportResponse
+	(*anypb.Any)(nil),             // 4: google.protobuf.Any
+	(*status.Status)(nil),         // 5: google.rpc.Status
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_contracts_collector_collectorpb_collector_proto_depIdxs = []int32{
+	4,  // 0: contracts.collector.Call.request:type_name -> google.protobuf.Any
+	4,  // 1: contracts.collector.Call.response:type_name -> google.protobuf.Any
+	5,  // 2: contracts.collector.Call.status:type_name -> google.rpc.Status
+	6,  // 3: contracts.collector.Call.start_time:type_name -> google.protobuf.Timestamp
+	6,  // 4: contracts.collector.Call.end_time:type_name -> google.protobuf.Timestamp
+	6,  // 5: contracts.collector.Call.deadline:type_name -> google.protobuf.Timestamp
+	1,  // 6: contracts.collector.Call.request_metadata:type_name -> contracts.collector.MetadataEntry
+	1,  // 7: contracts.collector.Call.header:type_name -> contracts.collector.MetadataEntry
+	1,  // 8: contracts.collector.Call.trailer:type_name -> contracts.collector.MetadataEntry
+	0,  // 9: contracts.collector.ReportRequest.call:type_name -> contracts.collector.Call
+	0,  // 10: contracts.collector.ReportRequest.calls:type_name -> contracts.collector.Call
+	2,  // 11: contracts.collector.Collector.Report:input_type -> contracts.collector.ReportRequest
+	3,  // 12: contracts.collector.Collector.Report:output_type -> contracts.collector.ReportResponse
+	12, // [12:13] is the sub-list for method output_type
+	11, // [11:12] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_contracts_collector_collectorpb_collector_proto_init() }
+func file_contracts_collector_collectorpb_collector_proto_init() {
+	if File_contracts_collector_collectorpb_collector_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_collector_collectorpb_collector_proto_rawDesc), len(file_contracts_collector_collectorpb_collector_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contracts_collector_collectorpb_collector_proto_goTypes,
+		DependencyIndexes: file_contracts_collector_collectorpb_collector_proto_depIdxs,
+		MessageInfos:      file_contracts_collector_collectorpb_collector_proto_msgTypes,
+	}.Build()
+	File_contracts_collector_collectorpb_collector_proto = out.File
+	file_contracts_collector_collectorpb_collector_proto_goTypes = nil
+	file_contracts_collector_collectorpb_collector_proto_depIdxs = nil
+}
@@ -0,0 +1,209 @@
+package collector
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	pb "github.com/shayanh/grpc-go-contracts/contracts/collector/collectorpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type server struct {
+	pb.UnimplementedCollectorServer
+
+	collector contracts.Collector
+}
+
+// NewServer returns an implementation of the Collector gRPC service that
+// delivers the received reports to the given collector. Register it using
+// collectorpb.RegisterCollectorServer.
+//
+// Requests and responses of the reported calls are decoded to their Go types
+// if the types are linked into the binary; otherwise they are left as *anypb.Any.
+func NewServer(c contracts.Collector) pb.CollectorServer {
+	return &server{collector: c}
+}
+
+func (s *server) Report(ctx context.Context, in *pb.ReportRequest) (*pb.ReportResponse, error) {
+	if in.Call == nil {
+		return nil, status.Error(codes.InvalidArgument, "report without call")
+	}
+	report := ReportFromProto(in)
+	if err := s.collector.Report(ctx, report); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pb.ReportResponse{}, nil
+}
+
+type client struct {
+	c pb.CollectorClient
+}
+
+// NewClient returns a contracts.Collector that reports calls to a remote
+// Collector gRPC service through the given connection. Requests and responses
+// that are not protocol buffer messages are not sent.
+//
+// The connection must not use the interceptors of the reporting ServerContract.
+func NewClient(cc grpc.ClientConnInterface) contracts.Collector {
+	return &client{c: pb.NewCollectorClient(cc)}
+}
+
+func (cl *client) Report(ctx context.Context, report *contracts.CallReport) error {
+	_, err := cl.c.Report(ctx, ReportToProto(report))
+	return err
+}
+
+// ReportToProto converts a call report to its protocol buffer representation.
+// Requests and responses that are not protocol buffer messages are dropped.
+func ReportToProto(report *contracts.CallReport) *pb.ReportRequest {
+	in := &pb.ReportRequest{
+		TraceId: report.TraceID,
+		Call:    toProto(report.Call),
+		Root:    report.Root,
+	}
+	for _, call := range report.Calls {
+		in.Calls = append(in.Calls, toProto(call))
+	}
+	return in
+}
+
+// ReportFromProto converts the protocol buffer representation of a call
+// report back to a call report. Requests and responses are decoded to their
+// Go types if the types are linked into the binary; otherwise they are left
+// as *anypb.Any.
+func ReportFromProto(in *pb.ReportRequest) *contracts.CallReport {
+	report := &contracts.CallReport{
+		TraceID: in.TraceId,
+		Call:    fromProto(in.Call),
+		Root:    in.Root,
+	}
+	calls := map[string]*contracts.UnaryRPCCall{report.Call.ID: report.Call}
+	for _, call := range in.Calls {
+		c := fromProto(call)
+		calls[c.ID] = c
+		report.Calls = append(report.Calls, c)
+	}
+	for i, call := range in.Calls {
+		if parent, ok := calls[call.ParentId]; ok {
+			report.Calls[i].Parent = parent
+		} else if call.ParentId != "" {
+			report.Calls[i].Parent = &contracts.UnaryRPCCall{ID: call.ParentId}
+		}
+	}
+	return report
+}
+
+func toProto(call *contracts.UnaryRPCCall) *pb.Call {
+	res := &pb.Call{
+		Id:         call.ID,
+		FullMethod: call.FullMethod,
+		Request:    toAny(call.Request),
+		Response:   toAny(call.Response),
+		Order:      int32(call.Order),
+		StartTime:  toTimestamp(call.StartTime),
+		EndTime:    toTimestamp(call.EndTime),
+		Deadline:   toTimestamp(call.Deadline),
+		Attempts:   int32(call.Attempts),
+
+		RequestMetadata: toMetadataEntries(call.RequestMetadata),
+		Header:          toMetadataEntries(call.Header),
+		Trailer:         toMetadataEntries(call.Trailer),
+	}
+	if call.Parent != nil {
+		res.ParentId = call.Parent.ID
+	}
+	if call.Error != nil {
+		res.Status = status.Convert(call.Error).Proto()
+	}
+	return res
+}
+
+func fromProto(call *pb.Call) *contracts.UnaryRPCCall {
+	res := &contracts.UnaryRPCCall{
+		ID:         call.Id,
+		FullMethod: call.FullMethod,
+		Request:    fromAny(call.Request),
+		Response:   fromAny(call.Response),
+		Order:      int(call.Order),
+		StartTime:  fromTimestamp(call.StartTime),
+		EndTime:    fromTimestamp(call.EndTime),
+		Deadline:   fromTimestamp(call.Deadline),
+		Attempts:   int(call.Attempts),
+
+		RequestMetadata: fromMetadataEntries(call.RequestMetadata),
+		Header:          fromMetadataEntries(call.Header),
+		Trailer:         fromMetadataEntries(call.Trailer),
+	}
+	res.Duration = res.EndTime.Sub(res.StartTime)
+	if call.Status != nil {
+		res.Error = status.ErrorProto(call.Status)
+	}
+	return res
+}
+
+func toAny(v interface{}) *anypb.Any {
+	m, ok := v.(proto.Message)
+	if !ok || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	a, err := anypb.New(m)
+	if err != nil {
+		return nil
+	}
+	return a
+}
+
+func fromAny(a *anypb.Any) interface{} {
+	if a == nil {
+		return nil
+	}
+	m, err := a.UnmarshalNew()
+	if err != nil {
+		return a
+	}
+	return m
+}
+
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func toMetadataEntries(md metadata.MD) []*pb.MetadataEntry {
+	var res []*pb.MetadataEntry
+	for k, v := range md {
+		res = append(res, &pb.MetadataEntry{Key: k, Values: v})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Key < res[j].Key
+	})
+	return res
+}
+
+func fromMetadataEntries(entries []*pb.MetadataEntry) metadata.MD {
+	if len(entries) == 0 {
+		return nil
+	}
+	md := metadata.MD{}
+	for _, e := range entries {
+		md.Append(e.Key, e.Values...)
+	}
+	return md
+}
@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+)
+
+// Node is an RPC call in the call tree of a request.
+type Node struct {
+	// Call is the RPC call. It is nil if the call has not been reported yet,
+	// e.g., the caller does not report to the collector.
+	Call *contracts.UnaryRPCCall
+	// Children are the downstream calls made while serving Call, in invocation order.
+	Children []*Node
+}
+
+// FullMethod returns the full RPC method string of the node's call.
+func (n *Node) FullMethod() string {
+	if n.Call == nil {
+		return ""
+	}
+	return n.Call.FullMethod
+}
+
+// Walk traverses the subtree rooted at n in depth-first order. The traversal
+// stops as soon as fn returns false.
+func (n *Node) Walk(fn func(*Node) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, child := range n.Children {
+		if !child.Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find returns all nodes of the subtree rooted at n that call the given method.
+// method is either a full method string or in the form of package.service/method.
+func (n *Node) Find(method string) []*Node {
+	var res []*Node
+	n.Walk(func(node *Node) bool {
+		if matchMethod(node.FullMethod(), method) {
+			res = append(res, node)
+		}
+		return true
+	})
+	return res
+}
+
+// HasPath returns true if the subtree rooted at n contains a chain of nested
+// calls to the given methods, e.g., HasPath("mynote.NoteService/GetNote",
+// "mynote.AuthService/Authenticate", "mynote.TokenStore/Lookup").
+func (n *Node) HasPath(methods ...string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+	for _, start := range n.Find(methods[0]) {
+		if start.hasChildPath(methods[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) hasChildPath(methods []string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+	for _, child := range n.Children {
+		if matchMethod(child.FullMethod(), methods[0]) && child.hasChildPath(methods[1:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchMethod(fullMethod, method string) bool {
+	return fullMethod != "" && strings.TrimPrefix(fullMethod, "/") == strings.TrimPrefix(method, "/")
+}
@@ -0,0 +1,204 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// reports is a Collector that keeps the reports.
+type reports struct {
+	mu      sync.Mutex
+	reports []*CallReport
+}
+
+func (r *reports) Report(ctx context.Context, report *CallReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name      string
+		collector bool
+		trusted   func(ctx context.Context) bool
+		wantRoot  bool
+	}{
+		{name: "collector", collector: true},
+		{name: "no collector"},
+		{name: "trusted caller", collector: true, trusted: func(context.Context) bool { return true }},
+		{name: "untrusted caller", collector: true, trusted: func(context.Context) bool { return false }, wantRoot: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := new(reports)
+			var opts []ServerOption
+			if tt.collector {
+				opts = append(opts, WithCollector(col))
+			}
+			front, _ := newServerContract(t, nil, opts...)
+			if tt.trusted != nil {
+				opts = append(opts, WithTrustedCallers(tt.trusted))
+			}
+			back, _ := newServerContract(t, nil, opts...)
+
+			var md metadata.MD
+			backCC := testservice.ServeMethods(t, backService, map[string]testservice.Handler{
+				"Lookup": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+					md, _ = metadata.FromIncomingContext(ctx)
+					return in, nil
+				},
+			}, []grpc.ServerOption{grpc.UnaryInterceptor(back.UnaryServerInterceptor())},
+				grpc.WithUnaryInterceptor(front.UnaryClientInterceptor()))
+			frontCC := serveFront(t, front, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+			for _, sc := range []*ServerContract{front, back} {
+				if err := sc.FlushReports(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := len(md.Get(traceIDHeader)) > 0; got != tt.collector {
+				t.Errorf("trace header sent = %v, want %v", got, tt.collector)
+			}
+			if !tt.collector {
+				return
+			}
+			if len(col.reports) != 2 {
+				t.Fatalf("got %d reports, want 2", len(col.reports))
+			}
+			frontReport, backReport := col.reports[0], col.reports[1]
+			if frontReport.Call.FullMethod != getMethod {
+				frontReport, backReport = backReport, frontReport
+			}
+			if frontReport.Call.FullMethod != getMethod || backReport.Call.FullMethod != lookupMethod {
+				t.Errorf("reported calls %s and %s, want %s and %s",
+					frontReport.Call.FullMethod, backReport.Call.FullMethod, getMethod, lookupMethod)
+			}
+			if !frontReport.Root || backReport.Root != tt.wantRoot {
+				t.Errorf("root = %v, %v, want true, %v", frontReport.Root, backReport.Root, tt.wantRoot)
+			}
+			if got := backReport.TraceID == frontReport.TraceID; got == tt.wantRoot {
+				t.Errorf("trace IDs %q and %q, want them to differ iff the caller is not trusted", backReport.TraceID, frontReport.TraceID)
+			}
+			if len(frontReport.Calls) != 1 || (frontReport.Calls[0].ID == backReport.Call.ID) == tt.wantRoot {
+				t.Errorf("downstream calls of the root %v do not match the reported call %s", frontReport.Calls, backReport.Call.ID)
+			}
+		})
+	}
+}
+
+// blockingCollector is a Collector whose Report signals started and then
+// blocks until release is closed or its context is done.
+type blockingCollector struct {
+	reports
+	started chan struct{}
+	release chan struct{}
+}
+
+func (c *blockingCollector) Report(ctx context.Context, report *CallReport) error {
+	c.started <- struct{}{}
+	select {
+	case <-c.release:
+		return c.reports.Report(ctx, report)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestReportQueue(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests int
+		timeout  time.Duration
+		release  bool
+		want     int
+		wantLogs []string
+	}{
+		{
+			// The first report is being delivered while the second waits in
+			// the queue, so the third does not fit.
+			name:     "slow collector",
+			requests: 3,
+			timeout:  time.Minute,
+			release:  true,
+			want:     2,
+			wantLogs: []string{"report queue of collector is full, dropping the report of " + getMethod},
+		},
+		{
+			name:     "timeout",
+			requests: 1,
+			timeout:  time.Millisecond,
+			want:     0,
+			wantLogs: []string{"reporting calls to collector failed: context deadline exceeded " + getMethod},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := &blockingCollector{started: make(chan struct{}, tt.requests), release: make(chan struct{})}
+			var logs []string
+			var mu sync.Mutex
+			sc := NewServerContract(func(args ...interface{}) {
+				mu.Lock()
+				defer mu.Unlock()
+				logs = append(logs, strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+			}, WithCollector(col), WithReportQueue(1, tt.timeout))
+			frontCC := serveFront(t, sc, testservice.Echo)
+
+			for i := 0; i < tt.requests; i++ {
+				if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+					t.Fatal(err)
+				}
+				if i == 0 {
+					<-col.started
+				}
+			}
+			if tt.release {
+				close(col.release)
+			}
+			if err := sc.FlushReports(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if len(col.reports.reports) != tt.want {
+				t.Errorf("got %d reports, want %d", len(col.reports.reports), tt.want)
+			}
+			if !slices.Equal(logs, tt.wantLogs) {
+				t.Errorf("logs = %q, want %q", logs, tt.wantLogs)
+			}
+		})
+	}
+}
+
+func TestFlushReportsCanceled(t *testing.T) {
+	col := &blockingCollector{started: make(chan struct{}, 1), release: make(chan struct{})}
+	sc, _ := newServerContract(t, nil, WithCollector(col))
+	frontCC := serveFront(t, sc, testservice.Echo)
+	if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sc.FlushReports(ctx); err != context.Canceled {
+		t.Errorf("FlushReports() = %v, want %v", err, context.Canceled)
+	}
+	close(col.release)
+	if err := sc.FlushReports(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,13 +2,55 @@ package contracts
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 )
 
 // Condition represents a pre or postcondition. Must be a function with the specified signature.
 type Condition interface{}
 
+// NamedCondition is a pre or postcondition with a name. The name identifies
+// the violations of the condition, see Violation.Name.
+type NamedCondition struct {
+	Name      string
+	Condition Condition
+}
+
+// Named returns a condition c named name.
+func Named(name string, c Condition) Condition {
+	return &NamedCondition{Name: name, Condition: c}
+}
+
+// ConditionError is the error of a violated named condition.
+type ConditionError struct {
+	Name string
+	Err  error
+}
+
+func (e *ConditionError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the condition.
+func (e *ConditionError) Unwrap() error {
+	return e.Err
+}
+
+// conditionFunc returns the function of a condition.
+func conditionFunc(c Condition) Condition {
+	if nc, ok := c.(*NamedCondition); ok {
+		return nc.Condition
+	}
+	return c
+}
+
 func invokeCondition(c Condition, args ...interface{}) error {
+	if nc, ok := c.(*NamedCondition); ok {
+		if err := invokeCondition(nc.Condition, args...); err != nil {
+			return &ConditionError{Name: nc.Name, Err: err}
+		}
+		return nil
+	}
 	v := reflect.ValueOf(c)
 	t := v.Type()
 	if t.NumIn() != len(args) {
@@ -19,8 +61,11 @@ func invokeCondition(c Condition, args ...interface{}) error {
 		expectedType := t.In(i)
 		if arg == nil {
 			argv[i] = reflect.New(expectedType).Elem()
-		} else {
-			argv[i] = reflect.ValueOf(arg)
+			continue
+		}
+		argv[i] = reflect.ValueOf(arg)
+		if !argv[i].Type().AssignableTo(expectedType) {
+			return fmt.Errorf("condition argument %d has type %s, but %s is given", i, expectedType, argv[i].Type())
 		}
 	}
 	res := v.Call(argv)
@@ -43,7 +88,7 @@ func isError(t reflect.Type) bool {
 
 // Precondition function signature is `func(req *Request) error`.
 func validatePreCondition(c Condition) error {
-	v := reflect.ValueOf(c)
+	v := reflect.ValueOf(conditionFunc(c))
 	if v.Kind() != reflect.Func {
 		return errors.New("PreCondition must be a function")
 	}
@@ -63,7 +108,7 @@ func validatePreCondition(c Condition) error {
 // Postcondition function signature is
 // `func(resp *Response, respErr error, req *Request, calls contracts.RPCCallHistory) error`.
 func validatePostCondition(c Condition) error {
-	v := reflect.ValueOf(c)
+	v := reflect.ValueOf(conditionFunc(c))
 	if v.Kind() != reflect.Func {
 		return errors.New("PostCondition must be a function")
 	}
@@ -0,0 +1,144 @@
+// Package contracttest provides helpers to assert contract violations in tests.
+//
+//	func TestGetNote(t *testing.T) {
+//	    sc := contracts.NewServerContract(t.Log)
+//	    sc.RegisterServiceContract(noteServiceContract)
+//	    rec := contracttest.Record(t, sc)
+//	    conn := contracttest.Serve(t, sc, func(s *grpc.Server) {
+//	        pb.RegisterNoteServiceServer(s, noteServer)
+//	    })
+//	    client := pb.NewNoteServiceClient(conn)
+//
+//	    client.GetNote(ctx, &pb.GetNoteRequest{NoteId: 1, Token: "valid"})
+//	    rec.AssertNoViolations(t)
+//
+//	    client.GetNote(ctx, &pb.GetNoteRequest{NoteId: -1, Token: "valid"})
+//	    rec.AssertViolation(t, "non-negative-note-id")
+//	}
+package contracttest
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Recorder is a contracts.Reporter that records violations.
+type Recorder struct {
+	mu         sync.Mutex
+	violations []*contracts.Violation
+}
+
+// Report records the violation.
+func (r *Recorder) Report(v *contracts.Violation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.violations = append(r.violations, v)
+}
+
+// Violations returns the recorded violations.
+func (r *Recorder) Violations() []*contracts.Violation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]*contracts.Violation, len(r.violations))
+	copy(res, r.violations)
+	return res
+}
+
+// Reset removes the recorded violations.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.violations = nil
+}
+
+// take returns the recorded violations and removes them.
+func (r *Recorder) take() []*contracts.Violation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := r.violations
+	r.violations = nil
+	return res
+}
+
+// AssertNoViolations fails the test if any violation is recorded since the
+// last assertion. The recorded violations are removed.
+func (r *Recorder) AssertNoViolations(t testing.TB) {
+	t.Helper()
+	if vs := r.take(); len(vs) > 0 {
+		t.Errorf("contracttest: expected no violations, got %d:\n\t%s", len(vs), format(vs))
+	}
+}
+
+// AssertViolation fails the test unless exactly one violation is recorded
+// since the last assertion and it is named name, see contracts.Violation.Name.
+// The recorded violations are removed.
+func (r *Recorder) AssertViolation(t testing.TB, name string) {
+	t.Helper()
+	vs := r.take()
+	if len(vs) != 1 || vs[0].Name != name {
+		t.Errorf("contracttest: expected exactly one violation named %q, got %d:\n\t%s", name, len(vs), format(vs))
+	}
+}
+
+func format(vs []*contracts.Violation) string {
+	var lines []string
+	for _, v := range vs {
+		lines = append(lines, "["+v.Name+"] "+v.Error())
+	}
+	return strings.Join(lines, "\n\t")
+}
+
+// Record adds a new Recorder to the server contract. The recorder is removed
+// when the test finishes.
+func Record(t testing.TB, sc *contracts.ServerContract) *Recorder {
+	r := new(Recorder)
+	t.Cleanup(sc.AddReporter(r))
+	return r
+}
+
+// NoViolations fails the test if the server contract reports any violation
+// during the rest of the test.
+func NoViolations(t testing.TB, sc *contracts.ServerContract) {
+	r := Record(t, sc)
+	t.Cleanup(func() {
+		t.Helper()
+		r.AssertNoViolations(t)
+	})
+}
+
+// Serve starts an in-process gRPC server monitored by the server contract and
+// returns a client connection to it. register registers the services to the
+// server, e.g., pb.RegisterNoteServiceServer(s, noteServer). The connection
+// uses the client interceptor of the server contract, so it can be used by
+// the services under test for their downstream calls too. The server and the
+// connection are closed when the test finishes.
+func Serve(t testing.TB, sc *contracts.ServerContract, register func(s *grpc.Server), opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.UnaryInterceptor(sc.UnaryServerInterceptor()))
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithChainUnaryInterceptor(sc.UnaryClientInterceptor()),
+	}, opts...)
+	conn, err := grpc.NewClient("passthrough:///contracttest", opts...)
+	if err != nil {
+		t.Fatalf("contracttest: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
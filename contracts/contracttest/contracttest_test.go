@@ -0,0 +1,198 @@
+package contracttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"google.golang.org/grpc"
+)
+
+// errorT records the errors of assertions instead of failing the test.
+type errorT struct {
+	testing.TB
+	errs []string
+}
+
+func (t *errorT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+type notes struct {
+	testpb.UnimplementedNotesServer
+	auth testpb.AuthClient
+}
+
+func (s *notes) GetNote(ctx context.Context, in *testpb.GetNoteRequest) (*testpb.Note, error) {
+	if in.Token != "" {
+		if _, err := s.auth.Authenticate(ctx, &testpb.AuthenticateRequest{Token: in.Token}); err != nil {
+			return nil, err
+		}
+	}
+	return &testpb.Note{NoteId: in.NoteId}, nil
+}
+
+type auth struct {
+	testpb.UnimplementedAuthServer
+}
+
+func (auth) Authenticate(ctx context.Context, in *testpb.AuthenticateRequest) (*testpb.AuthenticateResponse, error) {
+	return &testpb.AuthenticateResponse{UserId: 1}, nil
+}
+
+// serve serves Notes, which authenticates its callers using Auth, and
+// returns a client of Notes.
+func serve(t testing.TB) (*contracts.ServerContract, testpb.NotesClient) {
+	sc := contracts.NewServerContract(t.Log)
+	err := sc.RegisterServiceContract(&contracts.ServiceContract{
+		ServiceName: "contracts.test.Notes",
+		RPCContracts: []*contracts.UnaryRPCContract{{
+			MethodName: "GetNote",
+			PreConditions: []contracts.Condition{&contracts.NamedCondition{
+				Name: "non-negative-note-id",
+				Condition: func(req *testpb.GetNoteRequest) error {
+					if req.NoteId < 0 {
+						return errors.New("note_id is negative")
+					}
+					return nil
+				},
+			}},
+			PostConditions: []contracts.Condition{&contracts.NamedCondition{
+				Name: "authenticated",
+				Condition: func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+					if calls.Filter("contracts.test.Auth", "Authenticate").Empty() {
+						return errors.New("caller is not authenticated")
+					}
+					return nil
+				},
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &notes{}
+	conn := Serve(t, sc, func(gs *grpc.Server) {
+		testpb.RegisterNotesServer(gs, s)
+		testpb.RegisterAuthServer(gs, auth{})
+	})
+	s.auth = testpb.NewAuthClient(conn)
+	return sc, testpb.NewNotesClient(conn)
+}
+
+func TestRecorder(t *testing.T) {
+	tests := []struct {
+		name   string
+		reqs   []*testpb.GetNoteRequest
+		assert func(r *Recorder, t testing.TB)
+		want   []string
+	}{
+		{
+			name:   "no violations",
+			reqs:   []*testpb.GetNoteRequest{{NoteId: 1, Token: "t"}},
+			assert: (*Recorder).AssertNoViolations,
+		},
+		{
+			name:   "unexpected violation",
+			reqs:   []*testpb.GetNoteRequest{{NoteId: 1}},
+			assert: (*Recorder).AssertNoViolations,
+			want:   []string{"expected no violations, got 1:\n\t[authenticated] "},
+		},
+		{
+			name: "violation",
+			reqs: []*testpb.GetNoteRequest{{NoteId: -1, Token: "t"}},
+			assert: func(r *Recorder, t testing.TB) {
+				r.AssertViolation(t, "non-negative-note-id")
+			},
+		},
+		{
+			name: "other violation",
+			reqs: []*testpb.GetNoteRequest{{NoteId: 1}},
+			assert: func(r *Recorder, t testing.TB) {
+				r.AssertViolation(t, "non-negative-note-id")
+			},
+			want: []string{`expected exactly one violation named "non-negative-note-id", got 1:` + "\n\t[authenticated] "},
+		},
+		{
+			name: "too many violations",
+			reqs: []*testpb.GetNoteRequest{{NoteId: -1, Token: "t"}, {NoteId: -2, Token: "t"}},
+			assert: func(r *Recorder, t testing.TB) {
+				r.AssertViolation(t, "non-negative-note-id")
+			},
+			want: []string{`expected exactly one violation named "non-negative-note-id", got 2:`},
+		},
+		{
+			name: "reset",
+			reqs: []*testpb.GetNoteRequest{{NoteId: -1}},
+			assert: func(r *Recorder, t testing.TB) {
+				if got := len(r.Violations()); got != 2 {
+					t.Errorf("got %d violations, want 2", got)
+				}
+				r.Reset()
+				r.AssertNoViolations(t)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, client := serve(t)
+			r := Record(t, sc)
+			for _, req := range tt.reqs {
+				client.GetNote(context.Background(), req)
+			}
+			et := &errorT{TB: t}
+			tt.assert(r, et)
+			if len(et.errs) != len(tt.want) {
+				t.Fatalf("got errors %q, want %q", et.errs, tt.want)
+			}
+			for i, err := range et.errs {
+				if !strings.Contains(err, tt.want[i]) {
+					t.Errorf("got error %q, want it to contain %q", err, tt.want[i])
+				}
+			}
+			// Assertions remove the recorded violations.
+			if got := r.Violations(); len(got) != 0 {
+				t.Errorf("got %d violations after assertion", len(got))
+			}
+		})
+	}
+}
+
+func TestNoViolations(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *testpb.GetNoteRequest
+		want int
+	}{
+		{name: "valid", req: &testpb.GetNoteRequest{NoteId: 1, Token: "t"}},
+		{name: "violation", req: &testpb.GetNoteRequest{NoteId: -1}, want: 1},
+	}
+	for _, tt := range tests {
+		et := new(errorT)
+		t.Run(tt.name, func(t *testing.T) {
+			et.TB = t
+			sc, client := serve(t)
+			NoViolations(et, sc)
+			client.GetNote(context.Background(), tt.req)
+		})
+		if len(et.errs) != tt.want {
+			t.Errorf("%s: got errors %q, want %d", tt.name, et.errs, tt.want)
+		}
+	}
+}
+
+func TestRecordRemovesReporter(t *testing.T) {
+	sc, client := serve(t)
+	var r *Recorder
+	t.Run("record", func(t *testing.T) {
+		r = Record(t, sc)
+	})
+	client.GetNote(context.Background(), &testpb.GetNoteRequest{NoteId: -1})
+	if got := r.Violations(); len(got) != 0 {
+		t.Errorf("recorder got %d violations after its test finished", len(got))
+	}
+}
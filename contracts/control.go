@@ -0,0 +1,338 @@
+package contracts
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ViolationPolicy determines what a ServerContract or a ClientContract does
+// when contracts are violated.
+type ViolationPolicy int32
+
+const (
+	// LogViolations logs the violations and notifies the reporters. It is the
+	// default policy.
+	LogViolations ViolationPolicy = iota
+	// RejectViolations logs and reports the violations like LogViolations,
+	// and fails the RPCs that violate their contracts. RPCs that violate a
+	// check of the request, e.g., a precondition, are not handled, or not
+	// called by a ClientContract, and fail with codes.InvalidArgument. RPCs
+	// that violate a check of the response, e.g., a postcondition, fail with
+	// codes.Internal. Violations concerning downstream calls are only logged
+	// and reported.
+	RejectViolations
+	// IgnoreViolations disables checking contracts. The interceptors pass
+	// the calls through without recording them.
+	IgnoreViolations
+)
+
+func (p ViolationPolicy) String() string {
+	switch p {
+	case LogViolations:
+		return "log"
+	case RejectViolations:
+		return "reject"
+	case IgnoreViolations:
+		return "ignore"
+	}
+	return "unknown"
+}
+
+// ConditionState is the state of a condition of a served RPC. Conditions
+// are identified by the names of their violations, see Violation.Name, so
+// the unnamed pre and postconditions of an RPC share a state.
+type ConditionState struct {
+	// Service is the service of the served RPC, i.e., package.service.
+	Service string
+	// FullMethod is the served RPC, i.e., /package.service/method.
+	FullMethod string
+	Name       string
+	Enabled    bool
+	// Passed and Failed are the number of times the condition was checked
+	// and held or was violated, respectively.
+	Passed uint64
+	Failed uint64
+}
+
+type conditionKey struct {
+	fullMethod string
+	name       string
+}
+
+type conditionCounts struct {
+	passed uint64
+	failed uint64
+}
+
+// control is the state of a ServerContract that can be changed at runtime.
+type control struct {
+	policy int32
+
+	mu                 sync.RWMutex
+	disabledServices   map[string]bool
+	disabledConditions map[conditionKey]bool
+	counts             map[conditionKey]*conditionCounts
+}
+
+// ViolationPolicy returns the current violation policy of the server contract.
+func (sc *ServerContract) ViolationPolicy() ViolationPolicy {
+	return ViolationPolicy(atomic.LoadInt32(&sc.control.policy))
+}
+
+// SetViolationPolicy changes the violation policy of the server contract.
+// It takes effect for the RPCs served afterwards.
+func (sc *ServerContract) SetViolationPolicy(p ViolationPolicy) {
+	atomic.StoreInt32(&sc.control.policy, int32(p))
+}
+
+// ServiceEnabled reports whether the contracts of a service are checked.
+func (sc *ServerContract) ServiceEnabled(serviceName string) bool {
+	sc.control.mu.RLock()
+	defer sc.control.mu.RUnlock()
+	return !sc.control.disabledServices[serviceName]
+}
+
+// SetServiceEnabled enables or disables checking the contracts of a service,
+// i.e., package.service. The RPCs of a disabled service are passed through
+// without being checked or recorded. Services are enabled by default.
+func (sc *ServerContract) SetServiceEnabled(serviceName string, enabled bool) {
+	sc.control.mu.Lock()
+	defer sc.control.mu.Unlock()
+	if sc.control.disabledServices == nil {
+		sc.control.disabledServices = make(map[string]bool)
+	}
+	if enabled {
+		delete(sc.control.disabledServices, serviceName)
+	} else {
+		sc.control.disabledServices[serviceName] = true
+	}
+}
+
+// ConditionEnabled reports whether the named condition of a served RPC is
+// checked. Conditions disabled for all RPCs are not checked either.
+func (sc *ServerContract) ConditionEnabled(fullMethod, name string) bool {
+	sc.control.mu.RLock()
+	defer sc.control.mu.RUnlock()
+	return !sc.control.disabledConditions[conditionKey{fullMethod, name}] &&
+		!sc.control.disabledConditions[conditionKey{"", name}]
+}
+
+// SetConditionEnabled enables or disables the named condition of a served
+// RPC. The name is a condition name as in Violation.Name, e.g., the name of a
+// named condition or "latency". If fullMethod is empty, the condition is
+// enabled or disabled for all RPCs. Conditions are enabled by default.
+func (sc *ServerContract) SetConditionEnabled(fullMethod, name string, enabled bool) {
+	sc.control.mu.Lock()
+	defer sc.control.mu.Unlock()
+	if sc.control.disabledConditions == nil {
+		sc.control.disabledConditions = make(map[conditionKey]bool)
+	}
+	key := conditionKey{fullMethod, name}
+	if enabled {
+		delete(sc.control.disabledConditions, key)
+	} else {
+		sc.control.disabledConditions[key] = true
+	}
+}
+
+// checking reports whether the contracts of a served RPC are checked.
+func (sc *ServerContract) checking(fullMethod string) bool {
+	return sc.ViolationPolicy() != IgnoreViolations && sc.ServiceEnabled(serviceName(fullMethod))
+}
+
+// count counts the outcome of a check of a served RPC.
+func (sc *ServerContract) count(fullMethod, name string, passed bool) {
+	key := conditionKey{fullMethod, name}
+	sc.control.mu.RLock()
+	counts, ok := sc.control.counts[key]
+	sc.control.mu.RUnlock()
+	if !ok {
+		sc.control.mu.Lock()
+		if sc.control.counts == nil {
+			sc.control.counts = make(map[conditionKey]*conditionCounts)
+		}
+		if counts, ok = sc.control.counts[key]; !ok {
+			counts = new(conditionCounts)
+			sc.control.counts[key] = counts
+		}
+		sc.control.mu.Unlock()
+	}
+	if passed {
+		atomic.AddUint64(&counts.passed, 1)
+	} else {
+		atomic.AddUint64(&counts.failed, 1)
+	}
+}
+
+// resetCounts drops the counters of the conditions of a service.
+func (sc *ServerContract) resetCounts(service string) {
+	sc.control.mu.Lock()
+	defer sc.control.mu.Unlock()
+	for key := range sc.control.counts {
+		if serviceName(key.fullMethod) == service {
+			delete(sc.control.counts, key)
+		}
+	}
+}
+
+// ServiceContracts returns the registered service contracts, ordered by
+// service name.
+func (sc *ServerContract) ServiceContracts() []*ServiceContract {
+	var res []*ServiceContract
+	for _, svcContracts := range sc.table().serviceContracts {
+		res = append(res, svcContracts...)
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].ServiceName < res[j].ServiceName
+	})
+	return res
+}
+
+// Conditions returns the state of the conditions of the registered RPC
+// contracts, and of the other checks that were made, e.g., validation,
+// ordered by full method and name.
+func (sc *ServerContract) Conditions() []ConditionState {
+	seen := make(map[conditionKey]bool)
+	var keys []conditionKey
+	add := func(key conditionKey) {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	t := sc.table()
+	for fullMethod, c := range t.unaryRPCContracts {
+		for _, name := range sc.conditionNames(t, fullMethod, c) {
+			add(conditionKey{fullMethod, name})
+		}
+	}
+
+	sc.control.mu.RLock()
+	defer sc.control.mu.RUnlock()
+	for key := range sc.control.counts {
+		add(key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].fullMethod != keys[j].fullMethod {
+			return keys[i].fullMethod < keys[j].fullMethod
+		}
+		return keys[i].name < keys[j].name
+	})
+
+	res := make([]ConditionState, len(keys))
+	for i, key := range keys {
+		res[i] = ConditionState{
+			Service:    serviceName(key.fullMethod),
+			FullMethod: key.fullMethod,
+			Name:       key.name,
+			Enabled: !sc.control.disabledConditions[key] &&
+				!sc.control.disabledConditions[conditionKey{"", key.name}],
+		}
+		if counts, ok := sc.control.counts[key]; ok {
+			res[i].Passed = atomic.LoadUint64(&counts.passed)
+			res[i].Failed = atomic.LoadUint64(&counts.failed)
+		}
+	}
+	return res
+}
+
+// conditionNames returns the names of the conditions checked for an RPC
+// contract of the table.
+func (sc *ServerContract) conditionNames(t *contractTable, fullMethod string, c *UnaryRPCContract) []string {
+	var names []string
+	for _, cond := range c.PreConditions {
+		names = append(names, conditionName(cond, preConditionViolation))
+	}
+	for _, cond := range c.PostConditions {
+		names = append(names, conditionName(cond, postConditionViolation))
+	}
+	if sc.validator != nil {
+		names = append(names, validationViolation)
+	}
+	metadata := c.IncomingMetadata != nil || len(c.DownstreamMetadata) > 0
+	for _, svcContract := range t.serviceContracts[serviceName(fullMethod)] {
+		metadata = metadata || svcContract.IncomingMetadata != nil || len(svcContract.DownstreamMetadata) > 0
+		if svcContract.RequireDeadlinePropagation {
+			names = append(names, deadlineViolation)
+		}
+	}
+	if metadata {
+		names = append(names, metadataViolation)
+	}
+	if c.AllowedCodes != nil || len(c.StatusConditions) > 0 {
+		names = append(names, statusViolation)
+	}
+	if c.MaxLatency > 0 || len(c.LatencyObjectives) > 0 {
+		names = append(names, latencyViolation)
+	}
+	if c.Idempotency != nil {
+		names = append(names, idempotencyViolation)
+	}
+	if t.rpcs[fullMethod].callSequence != nil {
+		names = append(names, callSequenceViolation)
+	}
+	return names
+}
+
+// conditionName returns the name of a condition, or def if it is not named.
+func conditionName(c Condition, def string) string {
+	if nc, ok := c.(*NamedCondition); ok {
+		return nc.Name
+	}
+	return def
+}
+
+// checker checks the contracts of a single served RPC.
+type checker struct {
+	sc         *ServerContract
+	fullMethod string
+	req        interface{}
+	resp       interface{}
+	respErr    error
+	responded  bool
+	// violation is the first reported violation of the RPC.
+	violation *Violation
+}
+
+// respond sets the outcome of the handler for the checks of the response.
+func (c *checker) respond(resp interface{}, respErr error) {
+	c.resp, c.respErr, c.responded = resp, respErr, true
+	c.violation = nil
+}
+
+// enabled reports whether the named check is enabled for the RPC.
+func (c *checker) enabled(name string) bool {
+	return c.sc.ConditionEnabled(c.fullMethod, name)
+}
+
+// observe counts the outcome of the named check and reports its errors,
+// unless the check is disabled. Nil errors are ignored.
+func (c *checker) observe(name string, errs ...error) {
+	if !c.enabled(name) {
+		return
+	}
+	passed := true
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		passed = false
+		v := &Violation{Name: name, FullMethod: c.fullMethod, Err: err, Request: c.req,
+			Response: c.resp, ResponseError: c.respErr, responded: c.responded}
+		if c.sc.report(v) && c.violation == nil {
+			c.violation = v
+		}
+	}
+	c.sc.count(c.fullMethod, name, passed)
+}
+
+// rejected returns the first violation of the RPC if the RPC must be failed
+// because of it.
+func (c *checker) rejected() *Violation {
+	if c.violation == nil || c.sc.ViolationPolicy() != RejectViolations {
+		return nil
+	}
+	return c.violation
+}
@@ -0,0 +1,193 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// controlledContract is a contract of test.Front/Get with a named
+// precondition, an unnamed postcondition and a latency bound.
+func controlledContract() *ServiceContract {
+	return &ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName: "Get",
+			PreConditions: []Condition{Named("nonempty", func(req *wrapperspb.StringValue) error {
+				if req.GetValue() == "" {
+					return errors.New("empty request")
+				}
+				return nil
+			})},
+			PostConditions: []Condition{func(resp *wrapperspb.StringValue, respErr error, req *wrapperspb.StringValue, calls RPCCallHistory) error {
+				if resp.GetValue() == "bad" {
+					return errors.New("bad response")
+				}
+				return nil
+			}},
+			MaxLatency: time.Hour,
+		}},
+	}
+}
+
+func TestViolationPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ViolationPolicy
+		value    string
+		want     []string
+		wantCode codes.Code
+		handled  bool
+	}{
+		{name: "log precondition", policy: LogViolations, value: "", want: []string{"nonempty"}, wantCode: codes.OK, handled: true},
+		{name: "log postcondition", policy: LogViolations, value: "bad", want: []string{postConditionViolation}, wantCode: codes.OK, handled: true},
+		{name: "reject precondition", policy: RejectViolations, value: "", want: []string{"nonempty"}, wantCode: codes.InvalidArgument},
+		{name: "reject postcondition", policy: RejectViolations, value: "bad", want: []string{postConditionViolation}, wantCode: codes.Internal, handled: true},
+		{name: "reject nothing", policy: RejectViolations, value: "good", wantCode: codes.OK, handled: true},
+		{name: "ignore", policy: IgnoreViolations, value: "", wantCode: codes.OK, handled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{controlledContract()})
+			sc.SetViolationPolicy(tt.policy)
+			if got := sc.ViolationPolicy(); got != tt.policy {
+				t.Errorf("ViolationPolicy() = %s, want %s", got, tt.policy)
+			}
+			handled := false
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				handled = true
+				return in, nil
+			})
+			_, err := call(context.Background(), frontCC, getMethod, tt.value)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("got code %s, want %s", got, tt.wantCode)
+			}
+			if handled != tt.handled {
+				t.Errorf("handled = %v, want %v", handled, tt.handled)
+			}
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			for _, c := range sc.Conditions() {
+				if tt.policy == IgnoreViolations && c.Passed+c.Failed > 0 {
+					t.Errorf("condition %s of an ignored RPC was counted", c.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestViolationPolicyString(t *testing.T) {
+	for p, want := range map[ViolationPolicy]string{
+		LogViolations:       "log",
+		RejectViolations:    "reject",
+		IgnoreViolations:    "ignore",
+		ViolationPolicy(42): "unknown",
+	} {
+		if got := p.String(); got != want {
+			t.Errorf("ViolationPolicy(%d).String() = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestSetEnabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(sc *ServerContract)
+		want  []string
+	}{
+		{name: "enabled", setup: func(*ServerContract) {}, want: []string{"nonempty", postConditionViolation}},
+		{
+			name:  "service disabled",
+			setup: func(sc *ServerContract) { sc.SetServiceEnabled(frontService, false) },
+		},
+		{
+			name: "service enabled again",
+			setup: func(sc *ServerContract) {
+				sc.SetServiceEnabled(frontService, false)
+				sc.SetServiceEnabled(frontService, true)
+			},
+			want: []string{"nonempty", postConditionViolation},
+		},
+		{
+			name:  "other service disabled",
+			setup: func(sc *ServerContract) { sc.SetServiceEnabled(backService, false) },
+			want:  []string{"nonempty", postConditionViolation},
+		},
+		{
+			name:  "condition disabled",
+			setup: func(sc *ServerContract) { sc.SetConditionEnabled(getMethod, "nonempty", false) },
+			want:  []string{postConditionViolation},
+		},
+		{
+			name:  "condition disabled for all RPCs",
+			setup: func(sc *ServerContract) { sc.SetConditionEnabled("", postConditionViolation, false) },
+			want:  []string{"nonempty"},
+		},
+		{
+			name:  "condition of other RPC disabled",
+			setup: func(sc *ServerContract) { sc.SetConditionEnabled(lookupMethod, "nonempty", false) },
+			want:  []string{"nonempty", postConditionViolation},
+		},
+		{
+			name: "condition enabled again",
+			setup: func(sc *ServerContract) {
+				sc.SetConditionEnabled(getMethod, "nonempty", false)
+				sc.SetConditionEnabled(getMethod, "nonempty", true)
+			},
+			want: []string{"nonempty", postConditionViolation},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{controlledContract()})
+			tt.setup(sc)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				return wrapperspb.String("bad"), nil
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, ""); err != nil {
+				t.Fatal(err)
+			}
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			for _, c := range sc.Conditions() {
+				if c.Enabled != sc.ConditionEnabled(c.FullMethod, c.Name) {
+					t.Errorf("condition %s of %s: Enabled = %v, ConditionEnabled() = %v", c.Name, c.FullMethod, c.Enabled, !c.Enabled)
+				}
+			}
+		})
+	}
+}
+
+func TestConditions(t *testing.T) {
+	sc, _ := newServerContract(t, []*ServiceContract{controlledContract()})
+	sc.SetConditionEnabled(getMethod, latencyViolation, false)
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		return in, nil
+	})
+	for _, value := range []string{"a", "", "bad", "b"} {
+		if _, err := call(context.Background(), frontCC, getMethod, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Disabled conditions are listed but not counted.
+	want := []ConditionState{
+		{Service: frontService, FullMethod: getMethod, Name: "latency", Enabled: false},
+		{Service: frontService, FullMethod: getMethod, Name: "nonempty", Enabled: true, Passed: 3, Failed: 1},
+		{Service: frontService, FullMethod: getMethod, Name: postConditionViolation, Enabled: true, Passed: 3, Failed: 1},
+	}
+	if got := sc.Conditions(); !slices.Equal(got, want) {
+		t.Errorf("Conditions() =\n%+v\nwant:\n%+v", got, want)
+	}
+	if got := sc.ServiceContracts(); len(got) != 1 || got[0].ServiceName != frontService {
+		t.Errorf("ServiceContracts() = %v", got)
+	}
+}
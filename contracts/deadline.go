@@ -0,0 +1,60 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// incomingDeadline is the deadline of a served RPC whose service requires
+// deadline propagation.
+type incomingDeadline struct {
+	fullMethod string
+	deadline   time.Time
+	ok         bool
+}
+
+// requiresDeadlinePropagation returns true if any contract of the service
+// that serves the given method requires deadline propagation.
+func (t *contractTable) requiresDeadlinePropagation(fullMethod string) bool {
+	for _, svcContract := range t.serviceContracts[serviceName(fullMethod)] {
+		if svcContract.RequireDeadlinePropagation {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDeadline checks that a downstream call made while serving an RPC does
+// not outlive the served RPC. Calls that are not made with the context of a
+// served RPC are not checked.
+func (sc *ServerContract) checkDeadline(ctx context.Context, method string, req interface{}) {
+	in, ok := ctx.Value(deadlineKey).(incomingDeadline)
+	if !ok {
+		return
+	}
+	if !in.ok || !sc.ConditionEnabled(in.fullMethod, deadlineViolation) {
+		return
+	}
+	var err error
+	if deadline, ok := ctx.Deadline(); !ok {
+		err = fmt.Errorf("call to %s has no deadline, but the served RPC has deadline %s", method, in.deadline.Format(time.RFC3339Nano))
+	} else if deadline.After(in.deadline) {
+		err = fmt.Errorf("call to %s has deadline %s, which is %s later than the deadline of the served RPC",
+			method, deadline.Format(time.RFC3339Nano), deadline.Sub(in.deadline))
+	}
+	if err != nil {
+		sc.reportCall(deadlineViolation, err, in.fullMethod, method, req)
+	}
+	sc.count(in.fullMethod, deadlineViolation, err == nil)
+}
+
+// serviceName returns the service name of a full method string.
+func serviceName(fullMethod string) string {
+	s := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(s, "/"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
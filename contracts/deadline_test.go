@@ -0,0 +1,146 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestDeadlinePropagation(t *testing.T) {
+	tests := []struct {
+		name     string
+		require  bool
+		deadline bool
+		// downstream returns the context of the downstream call made with
+		// the context of the served RPC.
+		downstream func(ctx context.Context) (context.Context, context.CancelFunc)
+		opts       []ServerOption
+		want       int
+	}{
+		{
+			name:       "propagated",
+			require:    true,
+			deadline:   true,
+			downstream: context.WithCancel,
+		},
+		{
+			name:     "shorter",
+			require:  true,
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(ctx, time.Second)
+			},
+		},
+		{
+			name:     "dropped",
+			require:  true,
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.WithoutCancel(ctx))
+			},
+			want: 1,
+		},
+		{
+			name:     "later",
+			require:  true,
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.WithoutCancel(ctx), time.Hour)
+			},
+			want: 1,
+		},
+		{
+			name:     "unrelated context",
+			require:  true,
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+		},
+		{
+			name:     "background context",
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			opts: []ServerOption{WithRequestContext()},
+			want: 1,
+		},
+		{
+			name: "todo context",
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.TODO())
+			},
+			opts: []ServerOption{WithRequestContext()},
+			want: 1,
+		},
+		{
+			name:       "request context",
+			downstream: context.WithCancel,
+			opts:       []ServerOption{WithRequestContext()},
+		},
+		{
+			name:    "served without deadline",
+			require: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.WithoutCancel(ctx))
+			},
+		},
+		{
+			name:     "not required",
+			deadline: true,
+			downstream: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.WithoutCancel(ctx))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName:                frontService,
+				RPCContracts:               []*UnaryRPCContract{{MethodName: "Get"}},
+				RequireDeadlinePropagation: tt.require,
+			}}, tt.opts...)
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				ctx, cancel := tt.downstream(ctx)
+				defer cancel()
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+
+			ctx := context.Background()
+			if tt.deadline {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, time.Minute)
+				defer cancel()
+			}
+			if _, err := call(ctx, frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+			if got := v.errors(); len(got) != tt.want {
+				t.Errorf("violations = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	tests := []struct {
+		fullMethod string
+		want       string
+	}{
+		{"/test.Front/Get", "test.Front"},
+		{"test.Front/Get", "test.Front"},
+		{"/pkg.v1.Service/Method", "pkg.v1.Service"},
+		{"test.Front", "test.Front"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := serviceName(tt.fullMethod); got != tt.want {
+			t.Errorf("serviceName(%q) = %q, want %q", tt.fullMethod, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package contracts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expectation provides assertions over the invocation order of RPC calls.
+// Methods are specified either as full method strings or in the form of
+// package.service/method. The returned errors describe the actual sequence of calls.
+type Expectation struct {
+	calls CallSet
+}
+
+// Expect returns assertions over the calls in the call set.
+func (cs CallSet) Expect() Expectation {
+	return Expectation{calls: cs.Ordered()}
+}
+
+// Expect returns assertions over all invoked RPCs.
+func (h *RPCCallHistory) Expect() Expectation {
+	return h.All().Expect()
+}
+
+// Before asserts that every call to the second method is preceded by a call to
+// the first method. It holds if the second method is not called at all.
+func (e Expectation) Before(first, second string) error {
+	seenFirst := false
+	for i, call := range e.calls {
+		switch {
+		case matchMethod(call.FullMethod, first):
+			seenFirst = true
+		case matchMethod(call.FullMethod, second) && !seenFirst:
+			return fmt.Errorf("expected %s to be called before %s, but call #%d is not preceded by it; calls: %s",
+				first, second, i, e.sequence())
+		}
+	}
+	return nil
+}
+
+// Never asserts that the method is not called.
+func (e Expectation) Never(method string) error {
+	if n := e.count(method); n > 0 {
+		return fmt.Errorf("expected %s to be never called, but it was called %d times; calls: %s",
+			method, n, e.sequence())
+	}
+	return nil
+}
+
+// ExactlyOnce asserts that the method is called exactly once.
+func (e Expectation) ExactlyOnce(method string) error {
+	if n := e.count(method); n != 1 {
+		return fmt.Errorf("expected %s to be called exactly once, but it was called %d times; calls: %s",
+			method, n, e.sequence())
+	}
+	return nil
+}
+
+// AtMost asserts that the method is called at most n times.
+func (e Expectation) AtMost(n int, method string) error {
+	if cnt := e.count(method); cnt > n {
+		return fmt.Errorf("expected %s to be called at most %d times, but it was called %d times; calls: %s",
+			method, n, cnt, e.sequence())
+	}
+	return nil
+}
+
+func (e Expectation) count(method string) int {
+	n := 0
+	for _, call := range e.calls {
+		if matchMethod(call.FullMethod, method) {
+			n++
+		}
+	}
+	return n
+}
+
+// sequence returns a human readable representation of the calls.
+func (e Expectation) sequence() string {
+	methods := make([]string, len(e.calls))
+	for i, call := range e.calls {
+		methods[i] = strings.TrimPrefix(call.FullMethod, "/")
+	}
+	return "[" + strings.Join(methods, " ") + "]"
+}
+
+// matchMethod reports whether fullMethod is the given method. method is either
+// a full method string or in the form of package.service/method.
+func matchMethod(fullMethod, method string) bool {
+	return strings.TrimPrefix(fullMethod, "/") == strings.TrimPrefix(method, "/")
+}
@@ -0,0 +1,98 @@
+package contracts
+
+import (
+	"strings"
+	"testing"
+)
+
+// callSet returns calls to the given methods of test.Back, e.g., "Lookup",
+// in invocation order.
+func callSet(methods ...string) CallSet {
+	var cs CallSet
+	for i, m := range methods {
+		cs = append(cs, &UnaryRPCCall{FullMethod: "/" + backService + "/" + m, Order: i})
+	}
+	return cs
+}
+
+func TestExpectation(t *testing.T) {
+	const lookup, missing = "test.Back/Lookup", "test.Back/Missing"
+	tests := []struct {
+		name    string
+		calls   CallSet
+		check   func(Expectation) error
+		wantErr string
+	}{
+		{
+			name:  "before holds",
+			calls: callSet("Lookup", "Read", "Read"),
+			check: func(e Expectation) error { return e.Before(lookup, readMethod) },
+		},
+		{
+			name:  "before holds without second method",
+			calls: callSet("Lookup"),
+			check: func(e Expectation) error { return e.Before(lookup, readMethod) },
+		},
+		{
+			name:    "before violated",
+			calls:   callSet("Read", "Lookup", "Read"),
+			check:   func(e Expectation) error { return e.Before(lookup, readMethod) },
+			wantErr: "call #0 is not preceded by it; calls: [test.Back/Read test.Back/Lookup test.Back/Read]",
+		},
+		{
+			name:  "never holds",
+			calls: callSet("Lookup"),
+			check: func(e Expectation) error { return e.Never(missing) },
+		},
+		{
+			name:    "never violated",
+			calls:   callSet("Lookup", "Lookup"),
+			check:   func(e Expectation) error { return e.Never(lookup) },
+			wantErr: "it was called 2 times",
+		},
+		{
+			name:  "exactly once holds",
+			calls: callSet("Lookup", "Read"),
+			check: func(e Expectation) error { return e.ExactlyOnce(lookupMethod) },
+		},
+		{
+			name:    "exactly once violated",
+			calls:   callSet("Read"),
+			check:   func(e Expectation) error { return e.ExactlyOnce(lookupMethod) },
+			wantErr: "it was called 0 times",
+		},
+		{
+			name:  "at most holds",
+			calls: callSet("Lookup", "Lookup"),
+			check: func(e Expectation) error { return e.AtMost(2, lookup) },
+		},
+		{
+			name:    "at most violated",
+			calls:   callSet("Lookup", "Lookup", "Lookup"),
+			check:   func(e Expectation) error { return e.AtMost(2, lookup) },
+			wantErr: "at most 2 times, but it was called 3 times",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check(tt.calls.Expect())
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExpectOrdersCalls(t *testing.T) {
+	cs := callSet("Lookup", "Read")
+	cs[0], cs[1] = cs[1], cs[0]
+	if err := cs.Expect().Before(lookupMethod, readMethod); err != nil {
+		t.Errorf("Before ignored the invocation order: %v", err)
+	}
+	if cs[0].FullMethod != readMethod {
+		t.Error("Expect modified the call set")
+	}
+}
@@ -0,0 +1,229 @@
+// Package fuzz implements property-based testing of gRPC handlers driven by
+// their contracts.
+//
+// Check generates random requests for a method, keeps those that satisfy the
+// preconditions of the method, calls the handler in-process through the
+// interceptor of the ServerContract and fails the test if the ServerContract
+// reports a contract violation, e.g., a violated postcondition. The failing
+// request is shrunk to a minimal counterexample:
+//
+//	func TestGetNote(t *testing.T) {
+//	    fuzz.Check(t, serverContract, "/mynote.NoteService/GetNote", noteServer.GetNote)
+//	}
+//
+// Fuzz does the same using the native fuzzing engine of go test:
+//
+//	func FuzzGetNote(f *testing.F) {
+//	    fuzz.Fuzz(f, serverContract, "/mynote.NoteService/GetNote", noteServer.GetNote)
+//	}
+package fuzz
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultRuns is the default number of requests checked by Check.
+const DefaultRuns = 100
+
+// maxDiscards is the number of generated requests violating the
+// preconditions that are discarded per checked request before giving up.
+const maxDiscards = 10
+
+// maxShrinks is the maximum number of requests tried while shrinking a counterexample.
+const maxShrinks = 1000
+
+// Handler is a unary RPC handler, e.g., a method of a service implementation.
+type Handler[Req, Resp proto.Message] func(ctx context.Context, req Req) (Resp, error)
+
+type config struct {
+	runs int
+	seed int64
+}
+
+// Option configures Check and Fuzz.
+type Option func(*config)
+
+// WithRuns sets the number of requests checked by Check.
+func WithRuns(n int) Option {
+	return func(c *config) {
+		c.runs = n
+	}
+}
+
+// WithSeed sets the seed of the random generator of requests.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{runs: DefaultRuns, seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check checks the handler of the given full method, i.e.,
+// /package.service/method, against the contracts of sc with random requests.
+func Check[Req, Resp proto.Message](t testing.TB, sc *contracts.ServerContract, fullMethod string, handler Handler[Req, Resp], opts ...Option) {
+	t.Helper()
+	cfg := newConfig(opts)
+	ch := newChecker(sc, fullMethod, handler)
+	r := rand.New(rand.NewSource(cfg.seed))
+
+	checked, discarded := 0, 0
+	for checked < cfg.runs {
+		req := ch.generate(r)
+		if !ch.valid(req) {
+			discarded++
+			if discarded > maxDiscards*cfg.runs {
+				break
+			}
+			continue
+		}
+		checked++
+		if violations := ch.run(req); len(violations) > 0 {
+			ch.fail(t, req, violations, fmt.Sprintf("seed %d", cfg.seed))
+			return
+		}
+	}
+	if checked == 0 {
+		t.Fatalf("fuzz: no request satisfying the preconditions of %s was generated", fullMethod)
+	}
+}
+
+// Fuzz checks the handler of the given full method, i.e.,
+// /package.service/method, against the contracts of sc with the requests
+// generated by the fuzzing engine. The inputs of the fuzzing engine are the
+// wire encoding of the requests; inputs that cannot be decoded or violate
+// the preconditions are skipped. A few random requests are added to the seed corpus.
+func Fuzz[Req, Resp proto.Message](f *testing.F, sc *contracts.ServerContract, fullMethod string, handler Handler[Req, Resp], opts ...Option) {
+	cfg := newConfig(opts)
+	ch := newChecker(sc, fullMethod, handler)
+	r := rand.New(rand.NewSource(cfg.seed))
+	for i := 0; i < cfg.runs/10+1; i++ {
+		if req := ch.generate(r); ch.valid(req) {
+			data, err := proto.Marshal(req)
+			if err != nil {
+				f.Fatal(err)
+			}
+			f.Add(data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		req := ch.newRequest()
+		if err := proto.Unmarshal(data, req); err != nil || !ch.valid(req) {
+			t.Skip()
+		}
+		if violations := ch.run(req); len(violations) > 0 {
+			ch.fail(t, req, violations, "fuzz input")
+		}
+	})
+}
+
+type checker[Req, Resp proto.Message] struct {
+	sc          *contracts.ServerContract
+	fullMethod  string
+	handler     Handler[Req, Resp]
+	contract    *contracts.UnaryRPCContract
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func newChecker[Req, Resp proto.Message](sc *contracts.ServerContract, fullMethod string, handler Handler[Req, Resp]) *checker[Req, Resp] {
+	return &checker[Req, Resp]{
+		sc:          sc,
+		fullMethod:  fullMethod,
+		handler:     handler,
+		contract:    sc.RPCContract(fullMethod),
+		interceptor: sc.UnaryServerInterceptor(),
+	}
+}
+
+func (ch *checker[Req, Resp]) newRequest() Req {
+	var zero Req
+	return zero.ProtoReflect().New().Interface().(Req)
+}
+
+func (ch *checker[Req, Resp]) generate(r *rand.Rand) Req {
+	req := ch.newRequest()
+	protoutil.Fill(req.ProtoReflect(), r)
+	return req
+}
+
+// valid returns true if the request satisfies the preconditions.
+func (ch *checker[Req, Resp]) valid(req Req) bool {
+	return ch.contract == nil || len(ch.contract.CheckPreConditions(req)) == 0
+}
+
+// run calls the handler with the request and returns the reported violations.
+func (ch *checker[Req, Resp]) run(req Req) (violations []string) {
+	var mu sync.Mutex
+	remove := ch.sc.AddReporter(contracts.ReporterFunc(func(v *contracts.Violation) {
+		if v.FullMethod != ch.fullMethod {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		violations = append(violations, v.Err.Error())
+	}))
+	defer remove()
+
+	defer func() {
+		if r := recover(); r != nil {
+			violations = append(violations, fmt.Sprintf("handler panicked: %v", r))
+		}
+	}()
+	info := &grpc.UnaryServerInfo{FullMethod: ch.fullMethod}
+	ch.interceptor(context.Background(), proto.Clone(req), info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return ch.handler(ctx, req.(Req))
+	})
+	return violations
+}
+
+// fail shrinks the failing request and fails the test.
+func (ch *checker[Req, Resp]) fail(t testing.TB, req Req, violations []string, source string) {
+	t.Helper()
+	min, minViolations := ch.shrink(req, violations)
+	t.Fatalf("fuzz: request violates the contracts of %s (%s)\nrequest: %s\nviolations:\n\t%s",
+		ch.fullMethod, source, prototext.Format(min), strings.Join(minViolations, "\n\t"))
+}
+
+// shrink returns a minimal request that satisfies the preconditions and
+// still causes a violation, and its violations.
+func (ch *checker[Req, Resp]) shrink(req Req, violations []string) (Req, []string) {
+	tries := 0
+	for improved := true; improved && tries < maxShrinks; {
+		improved = false
+		for _, s := range shrinks(req.ProtoReflect()) {
+			if tries++; tries > maxShrinks {
+				break
+			}
+			cand := proto.Clone(req).(Req)
+			s(cand.ProtoReflect())
+			if !ch.valid(cand) {
+				continue
+			}
+			if v := ch.run(cand); len(v) > 0 {
+				req, violations = cand, v
+				improved = true
+				break
+			}
+		}
+	}
+	return req, violations
+}
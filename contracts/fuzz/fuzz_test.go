@@ -0,0 +1,207 @@
+package fuzz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+)
+
+const getNote = "/contracts.test.Notes/GetNote"
+
+// fatalT records the failure of a check instead of failing the test.
+type fatalT struct {
+	testing.TB
+	msg string
+}
+
+func (t *fatalT) Fatalf(format string, args ...interface{}) {
+	t.msg = fmt.Sprintf(format, args...)
+}
+
+func newServerContract(t *testing.T, pre func(req *testpb.GetNoteRequest) error) *contracts.ServerContract {
+	// Shrinking reports many violations, so they are not logged.
+	sc := contracts.NewServerContract(func(...interface{}) {})
+	err := sc.RegisterServiceContract(&contracts.ServiceContract{
+		ServiceName: "contracts.test.Notes",
+		RPCContracts: []*contracts.UnaryRPCContract{{
+			MethodName:    "GetNote",
+			PreConditions: []contracts.Condition{pre},
+			PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+				if respErr == nil && resp.NoteId != req.NoteId {
+					return fmt.Errorf("got note %d, want %d", resp.NoteId, req.NoteId)
+				}
+				return nil
+			}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sc
+}
+
+func positiveID(req *testpb.GetNoteRequest) error {
+	if req.NoteId <= 0 {
+		return errors.New("note_id must be positive")
+	}
+	return nil
+}
+
+func getNoteHandler(ctx context.Context, req *testpb.GetNoteRequest) (*testpb.Note, error) {
+	return &testpb.Note{NoteId: req.NoteId}, nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		pre     func(req *testpb.GetNoteRequest) error
+		handler Handler[*testpb.GetNoteRequest, *testpb.Note]
+		want    []string
+	}{
+		{
+			name:    "holds",
+			pre:     positiveID,
+			handler: getNoteHandler,
+		},
+		{
+			name: "shrunk counterexample",
+			pre:  positiveID,
+			handler: func(ctx context.Context, req *testpb.GetNoteRequest) (*testpb.Note, error) {
+				if req.NoteId > 100 {
+					return &testpb.Note{}, nil
+				}
+				return getNoteHandler(ctx, req)
+			},
+			want: []string{"request violates the contracts of " + getNote, "note_id:", "101\n\nviolations:", "got note 0, want 101"},
+		},
+		{
+			name: "panic",
+			pre:  positiveID,
+			handler: func(ctx context.Context, req *testpb.GetNoteRequest) (*testpb.Note, error) {
+				if req.Token != "" {
+					panic("token")
+				}
+				return getNoteHandler(ctx, req)
+			},
+			want: []string{"handler panicked: token"},
+		},
+		{
+			name: "unsatisfiable preconditions",
+			pre: func(req *testpb.GetNoteRequest) error {
+				return errors.New("never")
+			},
+			handler: getNoteHandler,
+			want:    []string{"no request satisfying the preconditions of " + getNote + " was generated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ft := &fatalT{TB: t}
+			Check(ft, newServerContract(t, tt.pre), getNote, tt.handler, WithSeed(1), WithRuns(50))
+			if tt.want == nil && ft.msg != "" {
+				t.Errorf("Check failed: %s", ft.msg)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(ft.msg, want) {
+					t.Errorf("Check failed with %q, want it to contain %q", ft.msg, want)
+				}
+			}
+		})
+	}
+}
+
+func FuzzGetNote(f *testing.F) {
+	sc := contracts.NewServerContract(f.Log)
+	err := sc.RegisterServiceContract(&contracts.ServiceContract{
+		ServiceName: "contracts.test.Notes",
+		RPCContracts: []*contracts.UnaryRPCContract{{
+			MethodName:    "GetNote",
+			PreConditions: []contracts.Condition{positiveID},
+		}},
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	Fuzz(f, sc, getNote, getNoteHandler, WithSeed(1))
+}
+
+func TestShrinks(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want []string
+	}{
+		{
+			name: "scalars",
+			msg:  `note_id: 10 title: "abcd" attachment: "xy"`,
+			want: []string{
+				`title:"abcd" attachment:"xy"`, `note_id:5 title:"abcd" attachment:"xy"`, `note_id:9 title:"abcd" attachment:"xy"`,
+				`note_id:10 attachment:"xy"`, `note_id:10 title:"ab" attachment:"xy"`, `note_id:10 title:"abc" attachment:"xy"`,
+				`note_id:10 title:"abcd"`, `note_id:10 title:"abcd" attachment:"x"`, `note_id:10 title:"abcd" attachment:"x"`,
+			},
+		},
+		{
+			name: "negative",
+			msg:  `note_id: -3`,
+			want: []string{``, `note_id:-1`, `note_id:-2`},
+		},
+		{
+			name: "minimal scalars",
+			msg:  `note_id: 1 title: "a" kind: KIND_LIST`,
+			want: []string{`title:"a" kind:KIND_LIST`, `note_id:1 kind:KIND_LIST`, `note_id:1 title:"a"`},
+		},
+		{
+			name: "list",
+			msg:  `tags: "a" tags: "b"`,
+			want: []string{``, `tags:"b"`, `tags:"a"`},
+		},
+		{
+			name: "map",
+			msg:  `labels: {key: "b" value: "v"} labels: {key: "a" value: "v"}`,
+			want: []string{``, `labels: {key: "b" value: "v"}`, `labels: {key: "a" value: "v"}`},
+		},
+		{
+			name: "message",
+			msg:  `author: {user_id: 4}`,
+			want: []string{``, `author:{}`, `author:{user_id:2}`, `author:{user_id:3}`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := mustParse(t, tt.msg)
+			got := shrinks(msg.ProtoReflect())
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d shrinks, want %d", len(got), len(tt.want))
+			}
+			for i, s := range got {
+				cand := proto.Clone(msg).(*testpb.Note)
+				s(cand.ProtoReflect())
+				want := new(testpb.Note)
+				if err := prototext.Unmarshal([]byte(tt.want[i]), want); err != nil {
+					t.Fatal(err)
+				}
+				if !proto.Equal(cand, want) {
+					t.Errorf("shrink #%d = {%v}, want {%v}", i, cand, want)
+				}
+			}
+			if !proto.Equal(msg, mustParse(t, tt.msg)) {
+				t.Errorf("shrinks modified the message to {%v}", msg)
+			}
+		})
+	}
+}
+
+func mustParse(t *testing.T, text string) *testpb.Note {
+	msg := new(testpb.Note)
+	if err := prototext.Unmarshal([]byte(text), msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
@@ -0,0 +1,143 @@
+package fuzz
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// shrink is a simplification of a message. It is applied to a copy of the
+// message it is generated for.
+type shrink func(m protoreflect.Message)
+
+// shrinks returns the simplifications of a message, simplest first. The
+// fields are simplified in the order of their numbers and map entries in the
+// order of their keys, so that shrinking is reproducible.
+func shrinks(m protoreflect.Message) []shrink {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Number() < fields[j].Number()
+	})
+
+	var res []shrink
+	for _, fd := range fields {
+		v := m.Get(fd)
+		res = append(res, func(m protoreflect.Message) {
+			m.Clear(fd)
+		})
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				res = append(res, func(m protoreflect.Message) {
+					removeElement(m.Mutable(fd).List(), i)
+				})
+			}
+			if fd.Message() != nil {
+				for i := 0; i < list.Len(); i++ {
+					for _, s := range shrinks(list.Get(i).Message()) {
+						res = append(res, func(m protoreflect.Message) {
+							s(m.Mutable(fd).List().Get(i).Message())
+						})
+					}
+				}
+			}
+		case fd.IsMap():
+			var keys []protoreflect.MapKey
+			v.Map().Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+				keys = append(keys, k)
+				return true
+			})
+			sort.Slice(keys, func(i, j int) bool {
+				return keys[i].String() < keys[j].String()
+			})
+			for _, k := range keys {
+				res = append(res, func(m protoreflect.Message) {
+					m.Mutable(fd).Map().Clear(k)
+				})
+			}
+		case fd.Message() != nil:
+			for _, s := range shrinks(v.Message()) {
+				res = append(res, func(m protoreflect.Message) {
+					s(m.Mutable(fd).Message())
+				})
+			}
+		default:
+			for _, smaller := range smaller(fd, v) {
+				res = append(res, func(m protoreflect.Message) {
+					m.Set(fd, smaller)
+				})
+			}
+		}
+	}
+	return res
+}
+
+func removeElement(list protoreflect.List, i int) {
+	for j := i + 1; j < list.Len(); j++ {
+		list.Set(j-1, list.Get(j))
+	}
+	list.Truncate(list.Len() - 1)
+}
+
+// smaller returns simpler non-zero values of a scalar field: the value halved
+// and the value one step closer to zero.
+func smaller(fd protoreflect.FieldDescriptor, v protoreflect.Value) []protoreflect.Value {
+	var res []protoreflect.Value
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		for _, n := range towardZero(v.Int()) {
+			res = append(res, protoreflect.ValueOfInt32(int32(n)))
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		for _, n := range towardZero(v.Int()) {
+			res = append(res, protoreflect.ValueOfInt64(n))
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		for _, n := range towardZeroUint(v.Uint()) {
+			res = append(res, protoreflect.ValueOfUint32(uint32(n)))
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		for _, n := range towardZeroUint(v.Uint()) {
+			res = append(res, protoreflect.ValueOfUint64(n))
+		}
+	case protoreflect.StringKind:
+		if s := []rune(v.String()); len(s) > 1 {
+			res = append(res, protoreflect.ValueOfString(string(s[:len(s)/2])), protoreflect.ValueOfString(string(s[:len(s)-1])))
+		}
+	case protoreflect.BytesKind:
+		if b := v.Bytes(); len(b) > 1 {
+			res = append(res, protoreflect.ValueOfBytes(append([]byte(nil), b[:len(b)/2]...)),
+				protoreflect.ValueOfBytes(append([]byte(nil), b[:len(b)-1]...)))
+		}
+	}
+	return res
+}
+
+func towardZero(n int64) []int64 {
+	var res []int64
+	if h := n / 2; h != 0 {
+		res = append(res, h)
+	}
+	if n > 1 {
+		res = append(res, n-1)
+	} else if n < -1 {
+		res = append(res, n+1)
+	}
+	return res
+}
+
+func towardZeroUint(n uint64) []uint64 {
+	var res []uint64
+	if h := n / 2; h != 0 {
+		res = append(res, h)
+	}
+	if n > 1 {
+		res = append(res, n-1)
+	}
+	return res
+}
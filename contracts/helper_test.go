@@ -0,0 +1,97 @@
+package contracts
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	frontService = "test.Front"
+	backService  = "test.Back"
+	getMethod    = "/test.Front/Get"
+	lookupMethod = "/test.Back/Lookup"
+	readMethod   = "/test.Back/Read"
+)
+
+// violations gathers the violations reported by a ServerContract.
+type violations struct {
+	mu sync.Mutex
+	vs []*Violation
+}
+
+func (v *violations) Report(violation *Violation) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.vs = append(v.vs, violation)
+}
+
+// names returns the names of the reported violations.
+func (v *violations) names() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var res []string
+	for _, violation := range v.vs {
+		res = append(res, violation.Name)
+	}
+	return res
+}
+
+// errors returns the messages of the reported violations.
+func (v *violations) errors() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var res []string
+	for _, violation := range v.vs {
+		res = append(res, violation.Err.Error())
+	}
+	return res
+}
+
+// newServerContract creates a ServerContract that logs to t and gathers its
+// violations, with the given service contracts registered.
+func newServerContract(t *testing.T, svcContracts []*ServiceContract, opts ...ServerOption) (*ServerContract, *violations) {
+	t.Helper()
+	v := new(violations)
+	sc := NewServerContract(t.Log, append([]ServerOption{WithReporter(v)}, opts...)...)
+	for _, svcContract := range svcContracts {
+		if err := sc.RegisterServiceContract(svcContract); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sc, v
+}
+
+// serveBack serves test.Back and returns a connection to it whose calls are
+// recorded by sc. Lookup and Read echo their input unless methods overrides
+// them. The interceptors given in opts run inside the interceptor of sc.
+func serveBack(t *testing.T, sc *ServerContract, methods map[string]testservice.Handler, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	all := map[string]testservice.Handler{"Lookup": testservice.Echo, "Read": testservice.Echo}
+	for name, h := range methods {
+		all[name] = h
+	}
+	opts = append([]grpc.DialOption{grpc.WithChainUnaryInterceptor(sc.UnaryClientInterceptor())}, opts...)
+	return testservice.ServeMethods(t, backService, all, nil, opts...)
+}
+
+// serveFront serves test.Front, whose Get method is checked by sc, and returns
+// a connection to it.
+func serveFront(t *testing.T, sc *ServerContract, get testservice.Handler) *grpc.ClientConn {
+	t.Helper()
+	return testservice.ServeMethods(t, frontService, map[string]testservice.Handler{"Get": get},
+		[]grpc.ServerOption{grpc.UnaryInterceptor(sc.UnaryServerInterceptor())})
+}
+
+// call calls a method of a test service, i.e., /package.service/method.
+func call(ctx context.Context, cc grpc.ClientConnInterface, fullMethod, value string) (string, error) {
+	return testservice.Invoke(ctx, cc, fullMethod, value)
+}
+
+func str(v string) *wrapperspb.StringValue {
+	return wrapperspb.String(v)
+}
@@ -0,0 +1,140 @@
+package contracts
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultIdempotencyCacheSize is the cache size of an IdempotencyContract that
+// has no cache size specified.
+const DefaultIdempotencyCacheSize = 1024
+
+// IdempotencyContract marks an RPC as idempotent: calls with the same
+// idempotency key must have the same outcome. A call must fail with the same
+// status code as the previous call with its key, or succeed with an equal
+// response if that call succeeded. The outcomes of recent calls are kept in a
+// bounded cache and responses are compared using proto.Equal. Calls that end
+// with Canceled, DeadlineExceeded or Unavailable are neither checked nor
+// cached, since their outcome may not be decided by the handler. Each
+// ServerContract keeps its own cache.
+type IdempotencyContract struct {
+	// KeyMetadata is the incoming metadata key that carries the idempotency key.
+	KeyMetadata string
+	// KeyField is the name of the request field that carries the idempotency
+	// key, e.g., "request_id". It is used if the key is not found in the metadata.
+	KeyField string
+	// CacheSize is the maximum number of idempotency keys kept in the cache.
+	// Defaults to DefaultIdempotencyCacheSize.
+	CacheSize int
+}
+
+// idempotencyCache is the cache of an idempotency contract of a registered
+// RPC contract.
+type idempotencyCache struct {
+	contract IdempotencyContract
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     list.List
+}
+
+type idempotencyEntry struct {
+	key  string
+	code codes.Code
+	resp proto.Message
+}
+
+func (ic *IdempotencyContract) validate() error {
+	if ic.KeyMetadata == "" && ic.KeyField == "" {
+		return errors.New("IdempotencyContract must specify KeyMetadata or KeyField")
+	}
+	if ic.CacheSize < 0 {
+		return errors.New("IdempotencyContract cache size must not be negative")
+	}
+	return nil
+}
+
+// key extracts the idempotency key of a request.
+func (ic *IdempotencyContract) key(ctx context.Context, req interface{}) (string, bool) {
+	if ic.KeyMetadata != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(ic.KeyMetadata); len(values) > 0 {
+			return values[0], true
+		}
+	}
+	m, ok := req.(proto.Message)
+	if ic.KeyField == "" || !ok {
+		return "", false
+	}
+	msg := m.ProtoReflect()
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(ic.KeyField))
+	if fd == nil || !msg.Has(fd) {
+		return "", false
+	}
+	return fmt.Sprint(msg.Get(fd).Interface()), true
+}
+
+func newIdempotencyCache(ic *IdempotencyContract) *idempotencyCache {
+	c := &idempotencyCache{contract: *ic, entries: make(map[string]*list.Element)}
+	if c.contract.CacheSize == 0 {
+		c.contract.CacheSize = DefaultIdempotencyCacheSize
+	}
+	return c
+}
+
+// check compares the outcome of a call with the outcome of the previous call
+// with the same idempotency key, if cached.
+func (c *idempotencyCache) check(ctx context.Context, req, resp interface{}, err error) error {
+	key, ok := c.contract.key(ctx, req)
+	if !ok {
+		return nil
+	}
+	entry := &idempotencyEntry{key: key, code: status.Code(err)}
+	switch entry.code {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Unavailable:
+		return nil
+	case codes.OK:
+		m, ok := resp.(proto.Message)
+		if !ok || !m.ProtoReflect().IsValid() {
+			return nil
+		}
+		entry.resp = m
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		prev := e.Value.(*idempotencyEntry)
+		switch {
+		case prev.code != entry.code && prev.code == codes.OK:
+			return fmt.Errorf("call with idempotency key %q failed with %s, but a previous call succeeded: %v", key, entry.code, prev.resp)
+		case prev.code != entry.code:
+			return fmt.Errorf("call with idempotency key %q ended with %s, but a previous call failed with %s", key, entry.code, prev.code)
+		case prev.code == codes.OK && !proto.Equal(prev.resp, entry.resp):
+			return fmt.Errorf("response for idempotency key %q differs from the response of a previous call: %v", key, prev.resp)
+		}
+		return nil
+	}
+
+	if entry.resp != nil {
+		entry.resp = proto.Clone(entry.resp)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.contract.CacheSize {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*idempotencyEntry).key)
+	}
+	return nil
+}
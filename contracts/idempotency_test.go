@@ -0,0 +1,202 @@
+package contracts
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestIdempotencyCache(t *testing.T) {
+	type call struct {
+		mdKey   string
+		req     string
+		resp    string
+		code    codes.Code
+		wantErr bool
+	}
+	tests := []struct {
+		name     string
+		contract IdempotencyContract
+		calls    []call
+	}{
+		{
+			name:     "metadata key",
+			contract: IdempotencyContract{KeyMetadata: "idempotency-key"},
+			calls: []call{
+				{mdKey: "a", req: "x", resp: "1"},
+				{mdKey: "a", req: "y", resp: "1"},
+				{mdKey: "b", req: "x", resp: "2"},
+				{mdKey: "a", req: "x", resp: "2", wantErr: true},
+				{mdKey: "b", req: "x", resp: "2"},
+			},
+		},
+		{
+			name:     "no metadata key",
+			contract: IdempotencyContract{KeyMetadata: "idempotency-key"},
+			calls:    []call{{req: "x", resp: "1"}, {req: "x", resp: "2"}},
+		},
+		{
+			name:     "field key",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls: []call{
+				{req: "x", resp: "1"},
+				{req: "y", resp: "2"},
+				{req: "x", resp: "1"},
+				{req: "x", resp: "3", wantErr: true},
+			},
+		},
+		{
+			name:     "metadata key before field key",
+			contract: IdempotencyContract{KeyMetadata: "idempotency-key", KeyField: "value"},
+			calls: []call{
+				{mdKey: "a", req: "x", resp: "1"},
+				{req: "x", resp: "2"},
+				{mdKey: "x", req: "y", resp: "2"},
+				{req: "a", resp: "2", wantErr: true},
+			},
+		},
+		{
+			name:     "unset field",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls:    []call{{resp: "1"}, {resp: "2"}},
+		},
+		{
+			name:     "unknown field",
+			contract: IdempotencyContract{KeyField: "request_id"},
+			calls:    []call{{req: "x", resp: "1"}, {req: "x", resp: "2"}},
+		},
+		{
+			name:     "evicted",
+			contract: IdempotencyContract{KeyField: "value", CacheSize: 2},
+			calls: []call{
+				{req: "x", resp: "1"},
+				{req: "y", resp: "1"},
+				{req: "x", resp: "1"},
+				{req: "z", resp: "1"},
+				{req: "x", resp: "2", wantErr: true},
+				{req: "y", resp: "2"},
+			},
+		},
+		{
+			name:     "failure after success",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls:    []call{{req: "x", resp: "1"}, {req: "x", code: codes.Internal, wantErr: true}},
+		},
+		{
+			name:     "success after failure",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls:    []call{{req: "x", code: codes.NotFound}, {req: "x", resp: "1", wantErr: true}},
+		},
+		{
+			name:     "same failure",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls: []call{
+				{req: "x", code: codes.NotFound},
+				{req: "x", code: codes.NotFound},
+				{req: "x", code: codes.AlreadyExists, wantErr: true},
+			},
+		},
+		{
+			name:     "transient failures",
+			contract: IdempotencyContract{KeyField: "value"},
+			calls: []call{
+				{req: "x", code: codes.Unavailable},
+				{req: "x", resp: "1"},
+				{req: "x", code: codes.DeadlineExceeded},
+				{req: "x", code: codes.Canceled},
+				{req: "x", resp: "2", wantErr: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newIdempotencyCache(&tt.contract)
+			for i, call := range tt.calls {
+				ctx := context.Background()
+				if call.mdKey != "" {
+					ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tt.contract.KeyMetadata, call.mdKey))
+				}
+				req := new(wrapperspb.StringValue)
+				if call.req != "" {
+					req.Value = call.req
+				}
+				var resp *wrapperspb.StringValue
+				var callErr error
+				if call.code == codes.OK {
+					resp = str(call.resp)
+				} else {
+					callErr = status.Error(call.code, "failed")
+				}
+				if err := c.check(ctx, req, resp, callErr); (err != nil) != call.wantErr {
+					t.Errorf("call #%d: got error %v, want error %v", i, err, call.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestIdempotencyContractValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		contract IdempotencyContract
+		wantErr  bool
+	}{
+		{"metadata key", IdempotencyContract{KeyMetadata: "idempotency-key"}, false},
+		{"field key", IdempotencyContract{KeyField: "request_id", CacheSize: 10}, false},
+		{"no key", IdempotencyContract{CacheSize: 10}, true},
+		{"negative cache size", IdempotencyContract{KeyField: "request_id", CacheSize: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.contract.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdempotencyContract(t *testing.T) {
+	svcContract := &ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName:  "Get",
+			Idempotency: &IdempotencyContract{KeyMetadata: "idempotency-key"},
+		}},
+	}
+	// Each server returns a different response for the same request, which
+	// is only a violation within a single server.
+	serve := func(resp string) (func(key string), *violations) {
+		sc, v := newServerContract(t, []*ServiceContract{svcContract})
+		n := 0
+		frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			n++
+			if n > 2 {
+				return str(resp + "-changed"), nil
+			}
+			return str(resp), nil
+		})
+		return func(key string) {
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "idempotency-key", key)
+			if _, err := call(ctx, frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+		}, v
+	}
+	first, firstViolations := serve("first")
+	second, secondViolations := serve("second")
+	first("a")
+	second("a")
+	first("a")
+	second("b")
+	first("a")
+	if got := firstViolations.errors(); len(got) != 1 {
+		t.Errorf("violations of first server = %v, want 1", got)
+	}
+	if got := secondViolations.errors(); got != nil {
+		t.Errorf("violations of second server = %v, want none", got)
+	}
+}
@@ -0,0 +1,158 @@
+package protoutil
+
+import (
+	"math"
+	"math/rand"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxDepth is the maximum depth of the nested messages that Fill generates.
+const maxDepth = 3
+
+// maxLen is the maximum length of the strings, bytes, lists and maps that Fill generates.
+const maxLen = 4
+
+var runes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 -_.@/é世")
+
+// Fill sets the fields of the message to random values. Boundary values,
+// e.g., zero and the maximum value of integers, are generated more often.
+func Fill(msg protoreflect.Message, r *rand.Rand) {
+	fill(msg, r, 0)
+}
+
+func fill(msg protoreflect.Message, r *rand.Rand, depth int) {
+	desc := msg.Descriptor()
+	oneofs := desc.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		if n := r.Intn(od.Fields().Len() + 1); n < od.Fields().Len() {
+			fillField(msg, od.Fields().Get(n), r, depth)
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			continue
+		}
+		// Leave some fields unset.
+		if r.Intn(4) == 0 {
+			continue
+		}
+		fillField(msg, fd, r, depth)
+	}
+}
+
+func fillField(msg protoreflect.Message, fd protoreflect.FieldDescriptor, r *rand.Rand, depth int) {
+	isMessage := fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind
+	switch {
+	case fd.IsMap():
+		isMessage = fd.MapValue().Message() != nil
+		if isMessage && depth >= maxDepth {
+			return
+		}
+		m := msg.Mutable(fd).Map()
+		for n := r.Intn(maxLen + 1); n > 0; n-- {
+			key := scalar(fd.MapKey(), r).MapKey()
+			if isMessage {
+				value := m.NewValue()
+				fill(value.Message(), r, depth+1)
+				m.Set(key, value)
+			} else {
+				m.Set(key, scalar(fd.MapValue(), r))
+			}
+		}
+	case fd.IsList():
+		if isMessage && depth >= maxDepth {
+			return
+		}
+		list := msg.Mutable(fd).List()
+		for n := r.Intn(maxLen + 1); n > 0; n-- {
+			if isMessage {
+				value := list.NewElement()
+				fill(value.Message(), r, depth+1)
+				list.Append(value)
+			} else {
+				list.Append(scalar(fd, r))
+			}
+		}
+	case isMessage:
+		if depth >= maxDepth {
+			return
+		}
+		fill(msg.Mutable(fd).Message(), r, depth+1)
+	default:
+		msg.Set(fd, scalar(fd, r))
+	}
+}
+
+// scalar returns a random value of a non-message field.
+func scalar(fd protoreflect.FieldDescriptor, r *rand.Rand) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(r.Intn(2) == 0)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(r.Intn(values.Len())).Number())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(integer(r, math.MinInt32, math.MaxInt32)))
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return protoreflect.ValueOfInt64(integer(r, math.MinInt64, math.MaxInt64))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(integer(r, 0, math.MaxUint32)))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if r.Intn(8) == 0 {
+			return protoreflect.ValueOfUint64(math.MaxUint64)
+		}
+		return protoreflect.ValueOfUint64(uint64(integer(r, 0, math.MaxInt64)))
+	case protoreflect.FloatKind:
+		return protoreflect.ValueOfFloat32(float32(float(r)))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float(r))
+	case protoreflect.StringKind:
+		s := make([]rune, r.Intn(maxLen*4+1))
+		for i := range s {
+			s[i] = runes[r.Intn(len(runes))]
+		}
+		return protoreflect.ValueOfString(string(s))
+	case protoreflect.BytesKind:
+		b := make([]byte, r.Intn(maxLen*4+1))
+		r.Read(b)
+		return protoreflect.ValueOfBytes(b)
+	}
+	return fd.Default()
+}
+
+// integer returns a random integer in [min, max], preferring small values and the bounds.
+func integer(r *rand.Rand, min, max int64) int64 {
+	switch r.Intn(8) {
+	case 0:
+		return min
+	case 1:
+		return max
+	case 2:
+		return 0
+	}
+	v := r.Int63n(1000) - 500
+	if v < min {
+		v = -v
+	}
+	return v
+}
+
+func float(r *rand.Rand) float64 {
+	switch r.Intn(8) {
+	case 0:
+		return 0
+	case 1:
+		return -1
+	case 2:
+		return math.MaxFloat32
+	}
+	return (r.Float64() - 0.5) * 1000
+}
@@ -0,0 +1,571 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contracts/internal/testpb/notes.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Kind int32
+
+const (
+	Kind_KIND_UNSPECIFIED Kind = 0
+	Kind_KIND_TEXT        Kind = 1
+	Kind_KIND_LIST        Kind = 2
+)
+
+// Enum value maps for Kind.
+var (
+	Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_TEXT",
+		2: "KIND_LIST",
+	}
+	Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_TEXT":        1,
+		"KIND_LIST":        2,
+	}
+)
+
+func (x Kind) Enum() *Kind {
+	p := new(Kind)
+	*p = x
+	return p
+}
+
+func (x Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_contracts_internal_testpb_notes_proto_enumTypes[0].Descriptor()
+}
+
+func (Kind) Type() protoreflect.EnumType {
+	return &file_contracts_internal_testpb_notes_proto_enumTypes[0]
+}
+
+func (x Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Kind.Descriptor instead.
+func (Kind) EnumDescriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{0}
+}
+
+type GetNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteRequest) Reset() {
+	*x = GetNoteRequest{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteRequest) ProtoMessage() {}
+
+func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetNoteRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNoteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Author struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Author) Reset() {
+	*x = Author{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Author) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Author) ProtoMessage() {}
+
+func (x *Author) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Author.ProtoReflect.Descriptor instead.
+func (*Author) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Author) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Author) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type Note struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Kind          Kind                   `protobuf:"varint,4,opt,name=kind,proto3,enum=contracts.test.Kind" json:"kind,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Author        *Author                `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	Attachment    []byte                 `protobuf:"bytes,8,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Note) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *Note) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetKind() Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Kind_KIND_UNSPECIFIED
+}
+
+func (x *Note) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Note) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Note) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Note) GetAttachment() []byte {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type ListNotesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotesRequest) Reset() {
+	*x = ListNotesRequest{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotesRequest) ProtoMessage() {}
+
+func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListNotesRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListNotesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotesResponse) Reset() {
+	*x = ListNotesResponse{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotesResponse) ProtoMessage() {}
+
+func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotesResponse.ProtoReflect.Descriptor instead.
+func (*ListNotesResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListNotesResponse) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+type AuthenticateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticateRequest) Reset() {
+	*x = AuthenticateRequest{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateRequest) ProtoMessage() {}
+
+func (x *AuthenticateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateRequest.ProtoReflect.Descriptor instead.
+func (*AuthenticateRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AuthenticateRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type AuthenticateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthenticateResponse) Reset() {
+	*x = AuthenticateResponse{}
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthenticateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthenticateResponse) ProtoMessage() {}
+
+func (x *AuthenticateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_internal_testpb_notes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthenticateResponse.ProtoReflect.Descriptor instead.
+func (*AuthenticateResponse) Descriptor() ([]byte, []int) {
+	return file_contracts_internal_testpb_notes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuthenticateResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+var File_contracts_internal_testpb_notes_proto protoreflect.FileDescriptor
+
+const file_contracts_internal_testpb_notes_proto_rawDesc = "" +
+	"\n" +
+	"%contracts/internal/testpb/notes.proto\x12\x0econtracts.test\"?\n" +
+	"\x0eGetNoteRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"5\n" +
+	"\x06Author\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xcc\x02\n" +
+	"\x04Note\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\x05R\x06noteId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12(\n" +
+	"\x04kind\x18\x04 \x01(\x0e2\x14.contracts.test.KindR\x04kind\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x128\n" +
+	"\x06labels\x18\x06 \x03(\v2 .contracts.test.Note.LabelsEntryR\x06labels\x12.\n" +
+	"\x06author\x18\a \x01(\v2\x16.contracts.test.AuthorR\x06author\x12\x1e\n" +
+	"\n" +
+	"attachment\x18\b \x01(\fR\n" +
+	"attachment\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"E\n" +
+	"\x10ListNotesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"?\n" +
+	"\x11ListNotesResponse\x12*\n" +
+	"\x05notes\x18\x01 \x03(\v2\x14.contracts.test.NoteR\x05notes\"+\n" +
+	"\x13AuthenticateRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"/\n" +
+	"\x14AuthenticateResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId*:\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tKIND_TEXT\x10\x01\x12\r\n" +
+	"\tKIND_LIST\x10\x022\x9e\x01\n" +
+	"\x05Notes\x12A\n" +
+	"\aGetNote\x12\x1e.contracts.test.GetNoteRequest\x1a\x14.contracts.test.Note\"\x00\x12R\n" +
+	"\tListNotes\x12 .contracts.test.ListNotesRequest\x1a!.contracts.test.ListNotesResponse\"\x002c\n" +
+	"\x04Auth\x12[\n" +
+	"\fAuthenticate\x12#.contracts.test.AuthenticateRequest\x1a$.contracts.test.AuthenticateResponse\"\x00B@Z>github.com/shayanh/grpc-go-contracts/contracts/internal/testpbb\x06proto3"
+
+var (
+	file_contracts_internal_testpb_notes_proto_rawDescOnce sync.Once
+	file_contracts_internal_testpb_notes_proto_rawDescData []byte
+)
+
+func file_contracts_internal_testpb_notes_proto_rawDescGZIP() []byte {
+	file_contracts_internal_testpb_notes_proto_rawDescOnce.Do(func() {
+		file_contracts_internal_testpb_notes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_internal_testpb_notes_proto_rawDesc), len(file_contracts_internal_testpb_notes_proto_rawDesc)))
+	})
+	return file_contracts_internal_testpb_notes_proto_rawDescData
+}
+
+var file_contracts_internal_testpb_notes_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_contracts_internal_testpb_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_contracts_internal_testpb_notes_proto_goTypes = []any{
+	(Kind)(0),                    // 0: contracts.test.Kind
+	(*GetNoteRequest)(nil),       // 1: contracts.test.GetNoteRequest
+	(*Author)(nil),               // 2: contracts.test.Author
+	(*Note)(nil),                 // 3: contracts.test.Note
+	(*ListNotesRequest)(nil),     // 4: contracts.test.ListNotesRequest
+	(*ListNotesResponse)(nil),    // 5: contracts.test.ListNotesResponse
+	(*AuthenticateRequest)(nil),  // 6: contracts.test.AuthenticateRequest
+	(*AuthenticateResponse)(nil), // 7: contracts.test.AuthenticateResponse
+	nil,                          // 8: contracts.test.Note.LabelsEntry
+}
+var file_contracts_internal_testpb_notes_proto_depIdxs = []int32{
+	0, // 0: contracts.test.Note.kind:type_name -> contracts.test.Kind
+	8, // 1: contracts.test.Note.labels:type_name -> contracts.test.Note.LabelsEntry
+	2, // 2: contracts.test.Note.author:type_name -> contracts.test.Author
+	3, // 3: contracts.test.ListNotesResponse.notes:type_name -> contracts.test.Note
+	1, // 4: contracts.test.Notes.GetNote:input_type -> contracts.test.GetNoteRequest
+	4, // 5: contracts.test.Notes.ListNotes:input_type -> contracts.test.ListNotesRequest
+	6, // 6: contracts.test.Auth.Authenticate:input_type -> contracts.test.AuthenticateRequest
+	3, // 7: contracts.test.Notes.GetNote:output_type -> contracts.test.Note
+	5, // 8: contracts.test.Notes.ListNotes:output_type -> contracts.test.ListNotesResponse
+	7, // 9: contracts.test.Auth.Authenticate:output_type -> contracts.test.AuthenticateResponse
+	7, // [7:10] is the sub-list for method output_type
+	4, // [4:7] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_contracts_internal_testpb_notes_proto_init() }
+func file_contracts_internal_testpb_notes_proto_init() {
+	if File_contracts_internal_testpb_notes_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_internal_testpb_notes_proto_rawDesc), len(file_contracts_internal_testpb_notes_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_contracts_internal_testpb_notes_proto_goTypes,
+		DependencyIndexes: file_contracts_internal_testpb_notes_proto_depIdxs,
+		EnumInfos:         file_contracts_internal_testpb_notes_proto_enumTypes,
+		MessageInfos:      file_contracts_internal_testpb_notes_proto_msgTypes,
+	}.Build()
+	File_contracts_internal_testpb_notes_proto = out.File
+	file_contracts_internal_testpb_notes_proto_goTypes = nil
+	file_contracts_internal_testpb_notes_proto_depIdxs = nil
+}
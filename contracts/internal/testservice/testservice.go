@@ -0,0 +1,89 @@
+// Package testservice provides gRPC services for tests. Their methods take
+// and return wrapperspb.StringValue messages, so they need no generated code.
+package testservice
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Handler handles the calls to a method of a test service.
+type Handler func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error)
+
+// Echo is a Handler that returns its input.
+func Echo(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+	return in, nil
+}
+
+// Desc returns the description of a service, i.e., package.service, with the
+// given methods.
+func Desc(service string, methods map[string]Handler) *grpc.ServiceDesc {
+	sd := &grpc.ServiceDesc{ServiceName: service, HandlerType: (*interface{})(nil)}
+	for name, h := range methods {
+		fullMethod := "/" + service + "/" + name
+		sd.Methods = append(sd.Methods, grpc.MethodDesc{
+			MethodName: name,
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(wrapperspb.StringValue)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return h(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+				return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+					return h(ctx, req.(*wrapperspb.StringValue))
+				})
+			},
+		})
+	}
+	return sd
+}
+
+// Serve serves the given service over an in-memory connection until the test
+// ends and returns a client connection to it.
+func Serve(t testing.TB, sd *grpc.ServiceDesc, impl interface{}, sopts []grpc.ServerOption, dopts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	return ServeFunc(t, func(s *grpc.Server) { s.RegisterService(sd, impl) }, sopts, dopts...)
+}
+
+// ServeFunc is like Serve, but register registers the services to the server.
+func ServeFunc(t testing.TB, register func(s *grpc.Server), sopts []grpc.ServerOption, dopts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(sopts...)
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	dopts = append(dopts, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	cc, err := grpc.NewClient("passthrough:///bufnet", dopts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+// ServeMethods serves a test service with the given methods, see Desc and Serve.
+func ServeMethods(t testing.TB, service string, methods map[string]Handler, sopts []grpc.ServerOption, dopts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	return Serve(t, Desc(service, methods), struct{}{}, sopts, dopts...)
+}
+
+// Invoke calls a method of a test service, i.e., /package.service/method.
+func Invoke(ctx context.Context, cc grpc.ClientConnInterface, fullMethod, value string, opts ...grpc.CallOption) (string, error) {
+	out := new(wrapperspb.StringValue)
+	err := cc.Invoke(ctx, fullMethod, wrapperspb.String(value), out, opts...)
+	return out.GetValue(), err
+}
@@ -0,0 +1,137 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyWindow is the window of a LatencyObjective that has no window specified.
+const DefaultLatencyWindow = time.Minute
+
+// latencyBuckets is the number of buckets the window of a latency objective
+// is divided into.
+const latencyBuckets = 60
+
+// LatencyObjective is a service level objective on the latency of an RPC,
+// e.g., 99 percent of the calls must finish within 100ms. The objective is
+// checked over the calls served in a sliding time window. Its breach is
+// reported once when the objective is breached, and its recovery is logged
+// once when the objective is met again. The window slides in steps
+// of a sixtieth of its duration. Each ServerContract checks the objective over
+// its own calls.
+type LatencyObjective struct {
+	// Percentile is the percentage of calls that must finish within Threshold, e.g., 99.
+	Percentile float64
+	// Threshold is the latency that Percentile percent of calls must not exceed.
+	Threshold time.Duration
+	// Window is the duration of the sliding window. Defaults to DefaultLatencyWindow.
+	Window time.Duration
+	// MinSamples is the minimum number of calls in the window for the
+	// objective to be checked. It prevents reporting breaches based on too few calls.
+	MinSamples int
+}
+
+func (o *LatencyObjective) validate() error {
+	if o.Percentile <= 0 || o.Percentile > 100 {
+		return errors.New("LatencyObjective percentile must be in (0, 100]")
+	}
+	if o.Threshold <= 0 {
+		return errors.New("LatencyObjective threshold must be positive")
+	}
+	if o.Window < 0 || o.MinSamples < 0 {
+		return errors.New("LatencyObjective window and minimum samples must not be negative")
+	}
+	return nil
+}
+
+// latencyWindow is the state of a latency objective of a registered RPC
+// contract. The calls are counted in fixed-size buckets, so that its size
+// does not depend on the number of calls.
+type latencyWindow struct {
+	objective LatencyObjective
+	width     time.Duration
+
+	mu       sync.Mutex
+	buckets  [latencyBuckets]latencyBucket
+	breached bool
+}
+
+type latencyBucket struct {
+	// index is the number of bucket widths since the Unix epoch at the start
+	// of the bucket.
+	index int64
+	total int
+	slow  int
+}
+
+func newLatencyWindow(o *LatencyObjective) *latencyWindow {
+	w := &latencyWindow{objective: *o}
+	if w.objective.Window == 0 {
+		w.objective.Window = DefaultLatencyWindow
+	}
+	w.width = w.objective.Window / latencyBuckets
+	if w.width <= 0 {
+		w.width = 1
+	}
+	return w
+}
+
+// observe adds the latency of a call to the window. It returns an error if
+// the objective has just been breached, and a message if the objective has
+// just been met again.
+func (w *latencyWindow) observe(now time.Time, latency time.Duration) (recovery string, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	o := &w.objective
+	index := now.UnixNano() / int64(w.width)
+	b := &w.buckets[(index%latencyBuckets+latencyBuckets)%latencyBuckets]
+	if b.index != index {
+		*b = latencyBucket{index: index}
+	}
+	b.total++
+	if latency > o.Threshold {
+		b.slow++
+	}
+
+	var total, slow int
+	for _, b := range w.buckets {
+		if index-b.index < latencyBuckets {
+			total += b.total
+			slow += b.slow
+		}
+	}
+	allowed := float64(total) * (100 - o.Percentile) / 100
+	breached := total >= o.MinSamples && float64(slow) > allowed
+	if breached == w.breached {
+		return "", nil
+	}
+	w.breached = breached
+	if !breached {
+		return fmt.Sprintf("p%g latency is within %s again over the last %s: %d of %d calls took longer",
+			o.Percentile, o.Threshold, o.Window, slow, total), nil
+	}
+	return "", fmt.Errorf("p%g latency exceeds %s over the last %s: %d of %d calls took longer",
+		o.Percentile, o.Threshold, o.Window, slow, total)
+}
+
+// checkLatency checks the latency of a served call against the latency part
+// of its contract. It also returns the messages of the objectives that have
+// been met again.
+func (s *rpcState) checkLatency(c *UnaryRPCContract, now time.Time, latency time.Duration) (errs []error, recoveries []string) {
+	if c.MaxLatency > 0 && latency > c.MaxLatency {
+		errs = append(errs, fmt.Errorf("call took %s, exceeding the limit of %s", latency, c.MaxLatency))
+	}
+	for _, w := range s.latency {
+		recovery, err := w.observe(now, latency)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if recovery != "" {
+			recoveries = append(recoveries, recovery)
+		}
+	}
+	return errs, recoveries
+}
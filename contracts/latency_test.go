@@ -0,0 +1,219 @@
+package contracts
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	type sample struct {
+		at      time.Duration
+		latency time.Duration
+		breach  bool
+		recover bool
+	}
+	const ms = time.Millisecond
+	tests := []struct {
+		name      string
+		objective LatencyObjective
+		samples   []sample
+	}{
+		{
+			name:      "met",
+			objective: LatencyObjective{Percentile: 50, Threshold: 100 * ms},
+			samples: []sample{
+				{0, 10 * ms, false, false},
+				{time.Second, 100 * ms, false, false},
+				{2 * time.Second, 20 * ms, false, false},
+			},
+		},
+		{
+			name:      "breached once",
+			objective: LatencyObjective{Percentile: 50, Threshold: 100 * ms},
+			samples: []sample{
+				{0, 10 * ms, false, false},
+				{time.Second, 200 * ms, false, false},
+				{2 * time.Second, 200 * ms, true, false},
+				{3 * time.Second, 200 * ms, false, false},
+			},
+		},
+		{
+			name:      "recovered",
+			objective: LatencyObjective{Percentile: 50, Threshold: 100 * ms},
+			samples: []sample{
+				{0, 200 * ms, true, false},
+				{0, 10 * ms, false, true},
+				{0, 10 * ms, false, false},
+				{0, 200 * ms, false, false},
+				{0, 200 * ms, true, false},
+			},
+		},
+		{
+			name:      "min samples",
+			objective: LatencyObjective{Percentile: 90, Threshold: 100 * ms, MinSamples: 3},
+			samples:   []sample{{0, 200 * ms, false, false}, {0, 200 * ms, false, false}, {0, 200 * ms, true, false}},
+		},
+		{
+			name:      "slow calls leave the window",
+			objective: LatencyObjective{Percentile: 50, Threshold: 100 * ms, Window: 10 * time.Second},
+			samples: []sample{
+				{0, 200 * ms, true, false},
+				{5 * time.Second, 10 * ms, false, true},
+				{10 * time.Second, 10 * ms, false, false},
+				{11 * time.Second, 200 * ms, false, false},
+				{16 * time.Second, 200 * ms, true, false},
+			},
+		},
+		{
+			name:      "default window",
+			objective: LatencyObjective{Percentile: 50, Threshold: 100 * ms},
+			samples: []sample{
+				{0, 200 * ms, true, false},
+				{59 * time.Second, 10 * ms, false, true},
+				{2 * time.Minute, 200 * ms, true, false},
+			},
+		},
+	}
+	base := time.Unix(1_700_000_000, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newLatencyWindow(&tt.objective)
+			for i, s := range tt.samples {
+				recovery, err := w.observe(base.Add(s.at), s.latency)
+				if (err != nil) != s.breach {
+					t.Errorf("sample #%d: got error %v, want breach %v", i, err, s.breach)
+				}
+				if (recovery != "") != s.recover {
+					t.Errorf("sample #%d: got recovery %q, want recovery %v", i, recovery, s.recover)
+				}
+			}
+		})
+	}
+}
+
+func TestLatencyObjectiveValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		objective LatencyObjective
+		wantErr   bool
+	}{
+		{"valid", LatencyObjective{Percentile: 99.9, Threshold: time.Millisecond}, false},
+		{"max percentile", LatencyObjective{Percentile: 100, Threshold: time.Millisecond}, false},
+		{"zero percentile", LatencyObjective{Threshold: time.Millisecond}, true},
+		{"large percentile", LatencyObjective{Percentile: 101, Threshold: time.Millisecond}, true},
+		{"no threshold", LatencyObjective{Percentile: 99}, true},
+		{"negative window", LatencyObjective{Percentile: 99, Threshold: time.Millisecond, Window: -1}, true},
+		{"negative samples", LatencyObjective{Percentile: 99, Threshold: time.Millisecond, MinSamples: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.objective.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// sleepFront serves test.Front whose Get sleeps for the duration in its request.
+func sleepFront(t *testing.T, sc *ServerContract) func(d time.Duration) {
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		d, err := time.ParseDuration(in.Value)
+		if err != nil {
+			return nil, err
+		}
+		time.Sleep(d)
+		return in, nil
+	})
+	return func(d time.Duration) {
+		if _, err := call(context.Background(), frontCC, getMethod, d.String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMaxLatency(t *testing.T) {
+	sc, v := newServerContract(t, []*ServiceContract{{
+		ServiceName:  frontService,
+		RPCContracts: []*UnaryRPCContract{{MethodName: "Get", MaxLatency: 50 * time.Millisecond}},
+	}})
+	get := sleepFront(t, sc)
+	get(0)
+	if got := v.errors(); got != nil {
+		t.Errorf("fast call has violations %v", got)
+	}
+	get(100 * time.Millisecond)
+	get(100 * time.Millisecond)
+	if got := v.errors(); len(got) != 2 {
+		t.Errorf("violations = %v, want 2", got)
+	}
+}
+
+func TestLatencyObjectivePerServerContract(t *testing.T) {
+	svcContract := &ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName:        "Get",
+			LatencyObjectives: []*LatencyObjective{{Percentile: 50, Threshold: 50 * time.Millisecond, MinSamples: 2}},
+		}},
+	}
+	slowSC, slowViolations := newServerContract(t, []*ServiceContract{svcContract})
+	fastSC, fastViolations := newServerContract(t, []*ServiceContract{svcContract})
+	slow, fast := sleepFront(t, slowSC), sleepFront(t, fastSC)
+
+	slow(100 * time.Millisecond)
+	fast(0)
+	fast(0)
+	slow(100 * time.Millisecond)
+	slow(100 * time.Millisecond)
+	if got := slowViolations.errors(); len(got) != 1 {
+		t.Errorf("violations of slow server = %v, want 1", got)
+	}
+	if got := fastViolations.errors(); got != nil {
+		t.Errorf("violations of fast server = %v, want none", got)
+	}
+}
+
+func TestLatencyObjectiveRecovery(t *testing.T) {
+	var mu sync.Mutex
+	var logs []interface{}
+	sc := NewServerContract(func(args ...interface{}) {
+		t.Log(args...)
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, args[0])
+	})
+	err := sc.RegisterServiceContract(&ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName:        "Get",
+			LatencyObjectives: []*LatencyObjective{{Percentile: 50, Threshold: 50 * time.Millisecond}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	get := sleepFront(t, sc)
+
+	get(100 * time.Millisecond)
+	get(100 * time.Millisecond)
+	get(0)
+	get(0)
+	get(0)
+	get(0)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(logs) != 2 {
+		t.Fatalf("logged %v, want a breach and a recovery", logs)
+	}
+	if _, ok := logs[0].(error); !ok {
+		t.Errorf("first log = %v, want the breach", logs[0])
+	}
+	if recovery, ok := logs[1].(string); !ok || !strings.Contains(recovery, "again") {
+		t.Errorf("second log = %v, want the recovery", logs[1])
+	}
+}
@@ -0,0 +1,158 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataContract is a contract on the metadata of RPCs. Keys are matched
+// case-insensitively.
+type MetadataContract struct {
+	// Required are the keys that must be present in the metadata.
+	Required []string
+	// Forbidden are the keys that must not be present in the metadata.
+	Forbidden []string
+	// Patterns maps keys to regular expressions that all of their values must
+	// fully match, e.g., {"authorization": "Bearer .+"}. Keys that are not
+	// present are not checked, unless they are required.
+	Patterns map[string]string
+}
+
+// DownstreamMetadataContract is a contract on the outgoing metadata of the
+// downstream calls made while serving an RPC.
+type DownstreamMetadataContract struct {
+	// Target is the service, i.e., package.service, or the method, i.e.,
+	// package.service/method, of the downstream calls that the contract applies
+	// to. Empty Target matches all downstream calls.
+	Target string
+
+	MetadataContract
+}
+
+// metadataMatcher is a MetadataContract compiled when it is registered.
+type metadataMatcher struct {
+	required  []string
+	forbidden []string
+	patterns  []metadataPattern
+}
+
+type metadataPattern struct {
+	key  string
+	expr string
+	re   *regexp.Regexp
+}
+
+func compileMetadata(mc *MetadataContract) (*metadataMatcher, error) {
+	m := &metadataMatcher{
+		required:  append([]string(nil), mc.Required...),
+		forbidden: append([]string(nil), mc.Forbidden...),
+	}
+	for key, expr := range mc.Patterns {
+		re, err := regexp.Compile("^(?:" + expr + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata pattern for %q: %v", key, err)
+		}
+		m.patterns = append(m.patterns, metadataPattern{key: key, expr: expr, re: re})
+	}
+	sort.Slice(m.patterns, func(i, j int) bool {
+		return m.patterns[i].key < m.patterns[j].key
+	})
+	return m, nil
+}
+
+func (m *metadataMatcher) check(md metadata.MD) []error {
+	var errs []error
+	for _, key := range m.required {
+		if len(md.Get(key)) == 0 {
+			errs = append(errs, fmt.Errorf("required metadata %q is missing", key))
+		}
+	}
+	for _, key := range m.forbidden {
+		if len(md.Get(key)) > 0 {
+			errs = append(errs, fmt.Errorf("forbidden metadata %q is present", key))
+		}
+	}
+	for _, p := range m.patterns {
+		for _, v := range md.Get(p.key) {
+			if !p.re.MatchString(v) {
+				errs = append(errs, fmt.Errorf("metadata %q value %q does not match %q", p.key, v, p.expr))
+			}
+		}
+	}
+	return errs
+}
+
+func validateDownstreamMetadata(contracts []*DownstreamMetadataContract) error {
+	for _, dc := range contracts {
+		if dc == nil {
+			return errors.New("DownstreamMetadataContract must not be nil")
+		}
+	}
+	return nil
+}
+
+// matchTarget reports whether fullMethod belongs to the target service or method.
+func matchTarget(fullMethod, target string) bool {
+	if target == "" {
+		return true
+	}
+	if strings.Contains(strings.TrimPrefix(target, "/"), "/") {
+		return matchMethod(fullMethod, target)
+	}
+	return serviceName(fullMethod) == target
+}
+
+// checkIncomingMetadata checks the incoming metadata of a served RPC against
+// the contracts of its service and method.
+func (t *contractTable) checkIncomingMetadata(ctx context.Context, chk *checker) {
+	fullMethod := chk.fullMethod
+	md, _ := metadata.FromIncomingContext(ctx)
+	var mcs []*MetadataContract
+	for _, svcContract := range t.serviceContracts[serviceName(fullMethod)] {
+		mcs = append(mcs, svcContract.IncomingMetadata)
+	}
+	if c, ok := t.unaryRPCContracts[fullMethod]; ok {
+		mcs = append(mcs, c.IncomingMetadata)
+	}
+	for _, mc := range mcs {
+		if mc != nil {
+			chk.observe(metadataViolation, t.metadata[mc].check(md)...)
+		}
+	}
+}
+
+// checkOutgoingMetadata checks the outgoing metadata of a downstream call
+// against the contracts of the served RPC, as they were when the RPC started.
+func (sc *ServerContract) checkOutgoingMetadata(ctx context.Context, method string, md metadata.MD, req interface{}) {
+	servedMethod, ok := ctx.Value(fullMethodKey).(string)
+	if !ok {
+		return
+	}
+	t, ok := ctx.Value(tableKey).(*contractTable)
+	if !ok {
+		return
+	}
+	var dcs []*DownstreamMetadataContract
+	for _, svcContract := range t.serviceContracts[serviceName(servedMethod)] {
+		dcs = append(dcs, svcContract.DownstreamMetadata...)
+	}
+	if c, ok := t.unaryRPCContracts[servedMethod]; ok {
+		dcs = append(dcs, c.DownstreamMetadata...)
+	}
+	for _, dc := range dcs {
+		if !matchTarget(method, dc.Target) || !sc.ConditionEnabled(servedMethod, metadataViolation) {
+			continue
+		}
+		errs := t.metadata[&dc.MetadataContract].check(md)
+		for _, err := range errs {
+			sc.reportCall(metadataViolation, fmt.Errorf("call to %s: %v", method, err), servedMethod, method, req)
+		}
+		sc.count(servedMethod, metadataViolation, len(errs) == 0)
+	}
+}
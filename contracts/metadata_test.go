@@ -0,0 +1,195 @@
+package contracts
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMetadataMatcher(t *testing.T) {
+	mc := &MetadataContract{
+		Required:  []string{"X-Tenant-ID"},
+		Forbidden: []string{"x-debug"},
+		Patterns:  map[string]string{"authorization": "Bearer .+", "x-tenant-id": `\d+`},
+	}
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want []string
+	}{
+		{
+			name: "valid",
+			md:   metadata.Pairs("x-tenant-id", "42", "authorization", "Bearer token"),
+		},
+		{
+			name: "optional key missing",
+			md:   metadata.Pairs("x-tenant-id", "42"),
+		},
+		{
+			name: "required key missing",
+			md:   metadata.Pairs("authorization", "Bearer token"),
+			want: []string{`required metadata "X-Tenant-ID" is missing`},
+		},
+		{
+			name: "forbidden key present",
+			md:   metadata.Pairs("x-tenant-id", "42", "x-debug", "1"),
+			want: []string{`forbidden metadata "x-debug" is present`},
+		},
+		{
+			name: "partial match",
+			md:   metadata.Pairs("x-tenant-id", "42", "authorization", "Basic Bearer token"),
+			want: []string{`metadata "authorization" value "Basic Bearer token" does not match "Bearer .+"`},
+		},
+		{
+			name: "every value is matched",
+			md:   metadata.Pairs("x-tenant-id", "42", "x-tenant-id", "acme"),
+			want: []string{`metadata "x-tenant-id" value "acme" does not match`},
+		},
+		{
+			name: "no metadata",
+			want: []string{"required metadata"},
+		},
+	}
+	m, err := compileMetadata(mc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := m.check(tt.md)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got errors %v, want %d errors", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tt.want[i]) {
+					t.Errorf("got error %q, want it to contain %q", err, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMatchTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   bool
+	}{
+		{"", true},
+		{backService, true},
+		{"test.Back/Lookup", true},
+		{"/test.Back/Lookup", true},
+		{"test.Back/Read", false},
+		{"test", false},
+		{frontService, false},
+	}
+	for _, tt := range tests {
+		if got := matchTarget(lookupMethod, tt.target); got != tt.want {
+			t.Errorf("matchTarget(%q, %q) = %v, want %v", lookupMethod, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestMetadataContract(t *testing.T) {
+	tests := []struct {
+		name       string
+		incoming   metadata.MD
+		downstream metadata.MD
+		want       int
+	}{
+		{
+			name:       "valid",
+			incoming:   metadata.Pairs("x-tenant-id", "42", "authorization", "Bearer token"),
+			downstream: metadata.Pairs("x-request-id", "1"),
+		},
+		{
+			name:       "service contract violated",
+			incoming:   metadata.Pairs("authorization", "Bearer token"),
+			downstream: metadata.Pairs("x-request-id", "1"),
+			want:       1,
+		},
+		{
+			name:       "rpc contract violated",
+			incoming:   metadata.Pairs("x-tenant-id", "42", "authorization", "token"),
+			downstream: metadata.Pairs("x-request-id", "1"),
+			want:       1,
+		},
+		{
+			name:     "downstream contract violated",
+			incoming: metadata.Pairs("x-tenant-id", "42", "authorization", "Bearer token"),
+			want:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName:      frontService,
+				IncomingMetadata: &MetadataContract{Required: []string{"x-tenant-id"}},
+				DownstreamMetadata: []*DownstreamMetadataContract{{
+					Target:           "test.Back/Lookup",
+					MetadataContract: MetadataContract{Required: []string{"x-request-id"}},
+				}},
+				RPCContracts: []*UnaryRPCContract{{
+					MethodName:       "Get",
+					IncomingMetadata: &MetadataContract{Patterns: map[string]string{"authorization": "Bearer .+"}},
+				}},
+			}})
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				// Read is not a target of the downstream contract.
+				if _, err := call(ctx, backCC, readMethod, in.Value); err != nil {
+					return nil, err
+				}
+				ctx = metadata.NewOutgoingContext(ctx, tt.downstream)
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.incoming)
+			if _, err := call(ctx, frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+			if got := v.errors(); len(got) != tt.want {
+				t.Errorf("violations = %v, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataContractRegistration(t *testing.T) {
+	sc := NewServerContract(t.Log)
+
+	tests := []struct {
+		name        string
+		svcContract *ServiceContract
+	}{
+		{
+			name: "invalid incoming pattern",
+			svcContract: &ServiceContract{
+				ServiceName:  backService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup", IncomingMetadata: &MetadataContract{Patterns: map[string]string{"x": "("}}}},
+			},
+		},
+		{
+			name: "invalid downstream pattern",
+			svcContract: &ServiceContract{
+				ServiceName: backService,
+				DownstreamMetadata: []*DownstreamMetadataContract{{
+					MetadataContract: MetadataContract{Patterns: map[string]string{"x": "["}},
+				}},
+			},
+		},
+		{
+			name:        "nil downstream contract",
+			svcContract: &ServiceContract{ServiceName: backService, DownstreamMetadata: []*DownstreamMetadataContract{nil}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := sc.RegisterServiceContract(tt.svcContract); err == nil {
+				t.Error("RegisterServiceContract accepted an invalid metadata contract")
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package mock implements mock gRPC services backed by their contracts.
+//
+// A mock service replies to the requests that satisfy the preconditions of
+// the service contract with canned responses, or with generated responses
+// that satisfy the postconditions. Requests that violate the preconditions
+// are rejected with codes.InvalidArgument. This lets consumers test against
+// a service without running it:
+//
+//	m, err := mock.New(&pb.AuthService_ServiceDesc, authServiceContract)
+//	s := grpc.NewServer()
+//	m.Register(s)
+//
+// The request and response types of the methods are looked up in the global
+// registry of protocol buffer types, so any service whose descriptor is
+// registered can be mocked. Only unary methods are supported.
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DefaultMaxAttempts is the default number of responses generated for a
+// request until one satisfies the postconditions.
+const DefaultMaxAttempts = 100
+
+// Option configures a mock service.
+type Option func(*Server)
+
+// WithSeed sets the seed of the random generator of responses.
+func WithSeed(seed int64) Option {
+	return func(s *Server) {
+		s.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithMaxAttempts sets the number of responses generated for a request until
+// one satisfies the postconditions. The request fails with codes.Internal if
+// none does.
+func WithMaxAttempts(n int) Option {
+	return func(s *Server) {
+		s.maxAttempts = n
+	}
+}
+
+type response struct {
+	msg proto.Message
+	err error
+}
+
+// Server is a mock gRPC service.
+type Server struct {
+	desc        *grpc.ServiceDesc
+	contracts   map[string]*contracts.UnaryRPCContract
+	maxAttempts int
+
+	mu        sync.Mutex
+	rand      *rand.Rand
+	responses map[string]response
+}
+
+// New creates a mock of the service described by sd. svcContract is the
+// contract of the service; it may be nil, in which case any request is
+// accepted and responses are generated randomly.
+func New(sd *grpc.ServiceDesc, svcContract *contracts.ServiceContract, opts ...Option) (*Server, error) {
+	if svcContract != nil && svcContract.ServiceName != sd.ServiceName {
+		return nil, fmt.Errorf("mock: contract of %s cannot be used for %s", svcContract.ServiceName, sd.ServiceName)
+	}
+	s := &Server{
+		contracts:   make(map[string]*contracts.UnaryRPCContract),
+		maxAttempts: DefaultMaxAttempts,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		responses:   make(map[string]response),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if svcContract != nil {
+		for _, c := range svcContract.RPCContracts {
+			s.contracts[c.MethodName] = c
+		}
+	}
+
+	s.desc = &grpc.ServiceDesc{
+		ServiceName: sd.ServiceName,
+		HandlerType: (*interface{})(nil),
+		Metadata:    sd.Metadata,
+	}
+	for _, m := range sd.Methods {
+		md, err := protoutil.FindMethod("/" + sd.ServiceName + "/" + m.MethodName)
+		if err != nil {
+			return nil, fmt.Errorf("mock: %v", err)
+		}
+		s.desc.Methods = append(s.desc.Methods, grpc.MethodDesc{
+			MethodName: m.MethodName,
+			Handler:    s.handler(md),
+		})
+	}
+	return s, nil
+}
+
+// Register registers the mock service to a gRPC server.
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	r.RegisterService(s.desc, s)
+}
+
+// Respond makes the mock return resp for every valid request to the method.
+// method is the method name only, without the service name or package name.
+// Requests fail with codes.Internal if resp violates the postconditions.
+func (s *Server) Respond(method string, resp proto.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = response{msg: resp}
+}
+
+// Fail makes the mock return err for every valid request to the method.
+// method is the method name only, without the service name or package name.
+// Requests fail with codes.Internal if err violates the postconditions.
+func (s *Server) Fail(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[method] = response{err: err}
+}
+
+func (s *Server) handler(md protoreflect.MethodDescriptor) func(interface{}, context.Context, func(interface{}) error, grpc.UnaryServerInterceptor) (interface{}, error) {
+	fullMethod := "/" + s.desc.ServiceName + "/" + string(md.Name())
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		req := protoutil.NewMessage(md.Input())
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return s.handle(md, req)
+		}
+		if interceptor == nil {
+			return handler(ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+func (s *Server) handle(md protoreflect.MethodDescriptor, req interface{}) (interface{}, error) {
+	method := string(md.Name())
+	c := s.contracts[method]
+	if c != nil {
+		if errs := c.CheckPreConditions(req); len(errs) > 0 {
+			return nil, status.Error(codes.InvalidArgument, errors.Join(errs...).Error())
+		}
+	}
+
+	s.mu.Lock()
+	canned, ok := s.responses[method]
+	// Each request generates its responses with its own generator, so that
+	// requests are not serialized while generating.
+	r := rand.New(rand.NewSource(s.rand.Int63()))
+	s.mu.Unlock()
+
+	if ok {
+		var resp proto.Message
+		if canned.err == nil {
+			resp = proto.Clone(canned.msg)
+		}
+		if c != nil {
+			if errs := c.CheckPostConditions(resp, canned.err, req, contracts.RPCCallHistory{}); len(errs) > 0 {
+				return nil, status.Errorf(codes.Internal, "mock: the canned response of %s violates the postconditions: %s",
+					method, joinErrors(errs))
+			}
+		}
+		if canned.err != nil {
+			return nil, canned.err
+		}
+		return resp, nil
+	}
+	var errs []error
+	for i := 0; i < s.maxAttempts; i++ {
+		resp := protoutil.NewMessage(md.Output())
+		// The first response is the empty message.
+		if i > 0 {
+			protoutil.Fill(resp.ProtoReflect(), r)
+		}
+		if c == nil {
+			return resp, nil
+		}
+		errs = c.CheckPostConditions(resp, nil, req, contracts.RPCCallHistory{})
+		if len(errs) == 0 {
+			return resp, nil
+		}
+	}
+	return nil, status.Errorf(codes.Internal, "mock: no response satisfying the postconditions of %s was generated: %s",
+		method, joinErrors(errs))
+}
+
+func joinErrors(errs []error) string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,236 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var notesContract = &contracts.ServiceContract{
+	ServiceName: "contracts.test.Notes",
+	RPCContracts: []*contracts.UnaryRPCContract{{
+		MethodName: "GetNote",
+		PreConditions: []contracts.Condition{func(req *testpb.GetNoteRequest) error {
+			if req.NoteId <= 0 {
+				return errors.New("note_id must be positive")
+			}
+			return nil
+		}},
+		PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+			if respErr == nil && resp.Title == "" {
+				return errors.New("note has no title")
+			}
+			return nil
+		}},
+	}},
+}
+
+func serve(t *testing.T, m *Server, sopts ...grpc.ServerOption) testpb.NotesClient {
+	return testpb.NewNotesClient(testservice.ServeFunc(t, func(s *grpc.Server) { m.Register(s) }, sopts))
+}
+
+func TestMock(t *testing.T) {
+	tests := []struct {
+		name     string
+		contract *contracts.ServiceContract
+		setup    func(m *Server)
+		req      *testpb.GetNoteRequest
+		wantCode codes.Code
+		// want checks the response of a successful call.
+		want func(note *testpb.Note) bool
+	}{
+		{
+			name:     "generated",
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{NoteId: 1},
+			want:     func(note *testpb.Note) bool { return note.Title != "" },
+		},
+		{
+			name:     "precondition violated",
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "canned response",
+			setup: func(m *Server) {
+				m.Respond("GetNote", &testpb.Note{NoteId: 1, Title: "todo"})
+			},
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{NoteId: 2},
+			want: func(note *testpb.Note) bool {
+				return proto.Equal(note, &testpb.Note{NoteId: 1, Title: "todo"})
+			},
+		},
+		{
+			name: "canned error",
+			setup: func(m *Server) {
+				m.Fail("GetNote", status.Error(codes.NotFound, "not found"))
+			},
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{NoteId: 2},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "canned response violating the postconditions",
+			setup: func(m *Server) {
+				m.Respond("GetNote", &testpb.Note{NoteId: 1})
+			},
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{NoteId: 2},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "canned error violating the postconditions",
+			setup: func(m *Server) {
+				m.Fail("GetNote", status.Error(codes.NotFound, "not found"))
+			},
+			contract: &contracts.ServiceContract{
+				ServiceName: "contracts.test.Notes",
+				RPCContracts: []*contracts.UnaryRPCContract{{
+					MethodName: "GetNote",
+					PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+						if status.Code(respErr) == codes.NotFound {
+							return errors.New("notes are never missing")
+						}
+						return nil
+					}},
+				}},
+			},
+			req:      &testpb.GetNoteRequest{NoteId: 2},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "canned error of invalid request",
+			setup: func(m *Server) {
+				m.Fail("GetNote", status.Error(codes.NotFound, "not found"))
+			},
+			contract: notesContract,
+			req:      &testpb.GetNoteRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "unsatisfiable postcondition",
+			contract: &contracts.ServiceContract{
+				ServiceName: "contracts.test.Notes",
+				RPCContracts: []*contracts.UnaryRPCContract{{
+					MethodName: "GetNote",
+					PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+						return errors.New("never")
+					}},
+				}},
+			},
+			req:      &testpb.GetNoteRequest{},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "no contract",
+			req:  &testpb.GetNoteRequest{},
+			want: func(note *testpb.Note) bool { return proto.Equal(note, &testpb.Note{}) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(&testpb.Notes_ServiceDesc, tt.contract, WithSeed(1), WithMaxAttempts(10))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			note, err := serve(t, m).GetNote(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("got error %v, want code %s", err, tt.wantCode)
+			}
+			if err == nil && !tt.want(note) {
+				t.Errorf("got unexpected response %v", note)
+			}
+		})
+	}
+}
+
+func TestMockConcurrent(t *testing.T) {
+	m, err := New(&testpb.Notes_ServiceDesc, notesContract)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := serve(t, m)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			note, err := client.GetNote(context.Background(), &testpb.GetNoteRequest{NoteId: 1})
+			if err != nil || note.Title == "" {
+				t.Errorf("GetNote() = %v, %v, want a note with a title", note, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMockError(t *testing.T) {
+	tests := []struct {
+		name     string
+		sd       *grpc.ServiceDesc
+		contract *contracts.ServiceContract
+		want     string
+	}{
+		{
+			name:     "contract of another service",
+			sd:       &testpb.Auth_ServiceDesc,
+			contract: notesContract,
+			want:     "contract of contracts.test.Notes cannot be used for contracts.test.Auth",
+		},
+		{
+			name: "unregistered service",
+			sd:   testservice.Desc("test.Back", map[string]testservice.Handler{"Lookup": testservice.Echo}),
+			want: "method /test.Back/Lookup",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.sd, tt.contract)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockServerContract(t *testing.T) {
+	m, err := New(&testpb.Notes_ServiceDesc, notesContract, WithSeed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var violations []string
+	sc := contracts.NewServerContract(t.Log, contracts.WithReporter(contracts.ReporterFunc(func(v *contracts.Violation) {
+		violations = append(violations, v.FullMethod+": "+v.Err.Error())
+	})))
+	if err := sc.RegisterServiceContract(notesContract); err != nil {
+		t.Fatal(err)
+	}
+	client := serve(t, m, grpc.UnaryInterceptor(sc.UnaryServerInterceptor()))
+
+	if _, err := client.GetNote(context.Background(), &testpb.GetNoteRequest{NoteId: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ListNotes(context.Background(), &testpb.ListNotesRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	client.GetNote(context.Background(), &testpb.GetNoteRequest{})
+	want := "/contracts.test.Notes/GetNote: note_id must be positive"
+	if len(violations) != 1 || violations[0] != want {
+		t.Errorf("violations = %q, want [%q]", violations, want)
+	}
+}
@@ -0,0 +1,131 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerOption configures how a ServerContract monitors its contracts.
+type ServerOption func(*ServerContract)
+
+// WithCollector makes the ServerContract report the RPC calls it records to
+// the given collector. Reports are delivered in the background once a request
+// finishes, right after its postconditions are checked, so that a slow
+// collector does not delay the requests. See WithReportQueue and
+// ServerContract.FlushReports.
+func WithCollector(c Collector) ServerOption {
+	return func(sc *ServerContract) {
+		sc.collector = c
+	}
+}
+
+// WithReportQueue sets the number of reports that may wait for delivery to
+// the collector, and the time delivering a single report may take. Reports
+// that do not fit in the queue are dropped and logged. The defaults are
+// DefaultReportQueueSize and DefaultReportTimeout.
+func WithReportQueue(size int, timeout time.Duration) ServerOption {
+	return func(sc *ServerContract) {
+		sc.reports.size = size
+		sc.reports.timeout = timeout
+	}
+}
+
+// WithTrustedCallers makes the ServerContract accept the trace headers of a
+// request only if trusted returns true for its context, e.g., by checking the
+// peer or the client certificate of the caller. Requests of other callers are
+// the roots of new call trees.
+//
+// By default the trace headers of all callers are accepted. A client can then
+// make its request look like a part of another call tree, so that the
+// collector never checks the contracts of its root RPC. Services that are
+// called by untrusted clients should use this option.
+func WithTrustedCallers(trusted func(ctx context.Context) bool) ServerOption {
+	return func(sc *ServerContract) {
+		sc.trustedCaller = trusted
+	}
+}
+
+// WithSnapshots makes the ServerContract store deep copies of the requests
+// and responses instead of references to them. Server requests are copied
+// before the handler runs and responses right after it returns; downstream
+// calls are copied when they are invoked and when they return. This way the
+// postconditions see what was actually sent over the wire, even if the
+// handler modifies the messages later. Only protocol buffer messages are copied.
+func WithSnapshots() ServerOption {
+	return func(sc *ServerContract) {
+		sc.snapshots = true
+	}
+}
+
+// WithRequestContext requires every call made through UnaryClientInterceptor
+// to use the context of a request served by the ServerContract, or a context
+// derived from it. Calls made with any other context, e.g.,
+// context.Background() or context.TODO() inside a handler, cannot be tied to
+// the served request, so they escape its contracts; with this option they are
+// reported instead. Use it only if the client connection is used by the
+// handlers alone.
+func WithRequestContext() ServerOption {
+	return func(sc *ServerContract) {
+		sc.requestContext = true
+	}
+}
+
+// Validator validates protocol buffer messages, e.g., against the rules
+// declared in their proto annotations. See package validate for an
+// implementation of buf.validate rules.
+type Validator interface {
+	// Validate returns an error if the message is not valid.
+	Validate(msg proto.Message) error
+}
+
+// WithValidator makes the ServerContract validate the requests and responses
+// of all served RPCs. Requests are validated as preconditions and the
+// responses of successful calls as postconditions.
+func WithValidator(v Validator) ServerOption {
+	return func(sc *ServerContract) {
+		sc.validator = v
+	}
+}
+
+// WithReporter makes the ServerContract notify the given reporter of the
+// contract violations it detects. See also ServerContract.AddReporter.
+func WithReporter(r Reporter) ServerOption {
+	return func(sc *ServerContract) {
+		sc.AddReporter(r)
+	}
+}
+
+// WithViolationPolicy sets the initial violation policy of the
+// ServerContract. See also ServerContract.SetViolationPolicy.
+func WithViolationPolicy(p ViolationPolicy) ServerOption {
+	return func(sc *ServerContract) {
+		sc.control.policy = int32(p)
+	}
+}
+
+// WithSampler makes the ServerContract check only the requests sampled by the
+// given sampler, unless the contract of the RPC has a sampler of its own. The
+// downstream calls of the requests that are not sampled are not recorded.
+// Recorded traffic is always checked when it is replayed. Note that the
+// requests sent by packages fuzz, pact and contracttest are sampled as well,
+// so tests should use server contracts without sampling.
+func WithSampler(s Sampler) ServerOption {
+	return func(sc *ServerContract) {
+		sc.sampler = s
+	}
+}
+
+// WithCompleteCallTrees makes a ServerContract that reports to a collector
+// check every request made by the downstream calls of a checked request of
+// another service, regardless of its sampler, so that the call trees gathered
+// by the collector are complete. Such requests are recognized by their trace
+// headers, which are only accepted from trusted callers if WithTrustedCallers
+// is used. Without it, the option must only be used if the headers cannot be
+// set by untrusted clients; otherwise any client can bypass sampling.
+func WithCompleteCallTrees() ServerOption {
+	return func(sc *ServerContract) {
+		sc.completeCallTrees = true
+	}
+}
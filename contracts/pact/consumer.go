@@ -0,0 +1,111 @@
+package pact
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var marshalOptions = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type interactionKey struct{}
+
+type interactionInfo struct {
+	description string
+	matchers    []Matcher
+}
+
+// Describe returns a context that makes the calls made with it recorded with
+// the given description and matchers.
+func Describe(ctx context.Context, description string, matchers ...Matcher) context.Context {
+	return context.WithValue(ctx, interactionKey{}, interactionInfo{description: description, matchers: matchers})
+}
+
+// Recorder records the calls of a consumer to a provider as interactions of
+// a contract file.
+type Recorder struct {
+	consumer, provider string
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	err          error
+}
+
+// NewRecorder creates a Recorder of the contract between the given consumer and provider.
+func NewRecorder(consumer, provider string) *Recorder {
+	return &Recorder{consumer: consumer, provider: provider}
+}
+
+// UnaryClientInterceptor returns a new unary client interceptor that records
+// the calls made by the client.
+func (r *Recorder) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		r.record(ctx, method, req, reply, err)
+		return err
+	}
+}
+
+func (r *Recorder) record(ctx context.Context, method string, req, reply interface{}, respErr error) {
+	info, _ := ctx.Value(interactionKey{}).(interactionInfo)
+	in := &Interaction{
+		Description: info.description,
+		Method:      method,
+		Matchers:    info.matchers,
+	}
+	var err error
+	in.Request, err = marshal(req)
+	if err == nil && respErr == nil {
+		in.Response, err = marshal(reply)
+	}
+	if respErr != nil {
+		s := status.Convert(respErr)
+		in.Status = newStatus(s.Code(), s.Message())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		if r.err == nil {
+			r.err = fmt.Errorf("pact: recording call to %s: %v", method, err)
+		}
+		return
+	}
+	r.interactions = append(r.interactions, in)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a protocol buffer message", v)
+	}
+	return marshalOptions.Marshal(m)
+}
+
+// File returns the contract file of the recorded interactions. It returns an
+// error if a call could not be recorded.
+func (r *Recorder) File() (*File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	interactions := make([]*Interaction, len(r.interactions))
+	copy(interactions, r.interactions)
+	return &File{Consumer: r.consumer, Provider: r.provider, Interactions: interactions}, nil
+}
+
+// WriteFile writes the contract file of the recorded interactions.
+func (r *Recorder) WriteFile(name string) error {
+	f, err := r.File()
+	if err != nil {
+		return err
+	}
+	return f.WriteFile(name)
+}
@@ -0,0 +1,147 @@
+package pact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// compare compares the expected response with the actual response, both in
+// the protobuf JSON format, and returns the mismatches.
+func compare(expected, actual []byte, matchers []Matcher) ([]string, error) {
+	ms := make(map[string]Matcher)
+	for _, m := range matchers {
+		ms[m.Path] = m
+	}
+	e, err := decode(expected)
+	if err != nil {
+		return nil, fmt.Errorf("expected response: %v", err)
+	}
+	a, err := decode(actual)
+	if err != nil {
+		return nil, fmt.Errorf("actual response: %v", err)
+	}
+	c := comparer{matchers: ms}
+	if err := c.compare("", e, a, false); err != nil {
+		return nil, err
+	}
+	return c.mismatches, nil
+}
+
+func decode(data []byte) (interface{}, error) {
+	if len(data) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+type comparer struct {
+	matchers   map[string]Matcher
+	mismatches []string
+}
+
+func (c *comparer) mismatch(path, format string, args ...interface{}) {
+	if path == "" {
+		path = "response"
+	}
+	c.mismatches = append(c.mismatches, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *comparer) compare(path string, expected, actual interface{}, like bool) error {
+	if m, ok := c.matchers[path]; ok {
+		switch m.Type {
+		case MatchType:
+			like = true
+		case MatchRegex:
+			re, err := regexp.Compile("^(?:" + m.Regex + ")$")
+			if err != nil {
+				return fmt.Errorf("matcher of %s: %v", path, err)
+			}
+			// The elements of repeated fields are matched one by one.
+			values, ok := actual.([]interface{})
+			if !ok {
+				values = []interface{}{actual}
+			}
+			for _, v := range values {
+				if s, ok := v.(string); !ok || !re.MatchString(s) {
+					c.mismatch(path, "%v does not match %q", v, m.Regex)
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("matcher of %s has unknown type %q", path, m.Type)
+		}
+	}
+
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			c.mismatch(path, "expected an object, got %v", actual)
+			return nil
+		}
+		keys := make([]string, 0, len(e))
+		for k := range e {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			av, ok := a[k]
+			if !ok {
+				c.mismatch(fieldPath, "missing field")
+				continue
+			}
+			if err := c.compare(fieldPath, e[k], av, like); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			c.mismatch(path, "expected a list, got %v", actual)
+			return nil
+		}
+		if like {
+			if len(e) > 0 && len(a) == 0 {
+				c.mismatch(path, "expected at least one element")
+			}
+			for i := 0; len(e) > 0 && i < len(a); i++ {
+				if err := c.compare(path, e[0], a[i], like); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if len(e) != len(a) {
+			c.mismatch(path, "expected %d elements, got %d", len(e), len(a))
+			return nil
+		}
+		for i := range e {
+			if err := c.compare(path, e[i], a[i], like); err != nil {
+				return err
+			}
+		}
+	default:
+		if like {
+			if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+				c.mismatch(path, "expected a value like %v, got %v", expected, actual)
+			}
+		} else if !reflect.DeepEqual(expected, actual) {
+			c.mismatch(path, "expected %v, got %v", expected, actual)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package pact
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		actual   string
+		matchers []Matcher
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "equal",
+			expected: `{"note_id": 1, "tags": ["a", "b"], "author": {"name": "x"}}`,
+			actual:   `{"author": {"name": "x"}, "tags": ["a", "b"], "note_id": 1}`,
+		},
+		{
+			name:     "extra fields",
+			expected: `{"note_id": 1}`,
+			actual:   `{"note_id": 1, "title": "todo"}`,
+		},
+		{
+			name:     "empty expected response",
+			expected: ``,
+			actual:   `{"note_id": 1}`,
+		},
+		{
+			name:     "different values",
+			expected: `{"note_id": 1, "author": {"name": "x", "user_id": "7"}}`,
+			actual:   `{"note_id": 2, "author": {"name": "x", "user_id": "8"}}`,
+			want:     []string{"author.user_id: expected 7, got 8", "note_id: expected 1, got 2"},
+		},
+		{
+			name:     "missing field",
+			expected: `{"note_id": 1, "title": "todo"}`,
+			actual:   `{"note_id": 1}`,
+			want:     []string{"title: missing field"},
+		},
+		{
+			name:     "different lengths",
+			expected: `{"tags": ["a", "b"]}`,
+			actual:   `{"tags": ["a"]}`,
+			want:     []string{"tags: expected 2 elements, got 1"},
+		},
+		{
+			name:     "different types",
+			expected: `{"author": {"name": "x"}, "tags": ["a"]}`,
+			actual:   `{"author": "x", "tags": "a"}`,
+			want:     []string{`author: expected an object, got x`, `tags: expected a list, got a`},
+		},
+		{
+			name:     "like scalar",
+			expected: `{"note_id": 1, "title": "todo"}`,
+			actual:   `{"note_id": 2, "title": "todo"}`,
+			matchers: []Matcher{Like("note_id")},
+		},
+		{
+			name:     "like scalar of another type",
+			expected: `{"note_id": 1}`,
+			actual:   `{"note_id": "1"}`,
+			matchers: []Matcher{Like("note_id")},
+			want:     []string{"note_id: expected a value like 1, got 1"},
+		},
+		{
+			name:     "like object",
+			expected: `{"author": {"name": "x", "user_id": "7"}}`,
+			actual:   `{"author": {"name": "y", "user_id": "8"}}`,
+			matchers: []Matcher{Like("author")},
+		},
+		{
+			name:     "like object with missing field",
+			expected: `{"author": {"name": "x", "user_id": "7"}}`,
+			actual:   `{"author": {"name": "y"}}`,
+			matchers: []Matcher{Like("author")},
+			want:     []string{"author.user_id: missing field"},
+		},
+		{
+			name:     "like list",
+			expected: `{"notes": [{"note_id": 1}]}`,
+			actual:   `{"notes": [{"note_id": 2}, {"note_id": 3}]}`,
+			matchers: []Matcher{Like("notes")},
+		},
+		{
+			name:     "like list elements",
+			expected: `{"notes": [{"note_id": 1}]}`,
+			actual:   `{"notes": [{"note_id": 2}, {"title": "x"}]}`,
+			matchers: []Matcher{Like("notes")},
+			want:     []string{"notes.note_id: missing field"},
+		},
+		{
+			name:     "like empty list",
+			expected: `{"notes": [{"note_id": 1}]}`,
+			actual:   `{"notes": []}`,
+			matchers: []Matcher{Like("notes")},
+			want:     []string{"notes: expected at least one element"},
+		},
+		{
+			name:     "like response",
+			expected: `{"note_id": 1}`,
+			actual:   `{"note_id": 5}`,
+			matchers: []Matcher{Like("")},
+		},
+		{
+			name:     "term",
+			expected: `{"author": {"name": "x"}}`,
+			actual:   `{"author": {"name": "user-12"}}`,
+			matchers: []Matcher{Term("author.name", `user-\d+`)},
+		},
+		{
+			name:     "term does not match",
+			expected: `{"author": {"name": "x"}}`,
+			actual:   `{"author": {"name": "a user-12"}}`,
+			matchers: []Matcher{Term("author.name", `user-\d+`)},
+			want:     []string{`author.name: a user-12 does not match "user-\\d+"`},
+		},
+		{
+			name:     "term of non-string",
+			expected: `{"note_id": 1}`,
+			actual:   `{"note_id": 12}`,
+			matchers: []Matcher{Term("note_id", `\d+`)},
+			want:     []string{`note_id: 12 does not match "\\d+"`},
+		},
+		{
+			name:     "term of repeated field",
+			expected: `{"tags": ["a"]}`,
+			actual:   `{"tags": ["b", "cc"]}`,
+			matchers: []Matcher{Term("tags", "[a-z]")},
+			want:     []string{`tags: cc does not match "[a-z]"`},
+		},
+		{
+			name:     "invalid term",
+			expected: `{"title": "x"}`,
+			actual:   `{"title": "x"}`,
+			matchers: []Matcher{Term("title", "(")},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown matcher",
+			expected: `{"title": "x"}`,
+			actual:   `{"title": "x"}`,
+			matchers: []Matcher{{Path: "title", Type: "prefix"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid response",
+			expected: `{"title": "x"}`,
+			actual:   `{"title": `,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := compare([]byte(tt.expected), []byte(tt.actual), tt.matchers)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("compare() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("compare() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
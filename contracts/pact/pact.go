@@ -0,0 +1,127 @@
+// Package pact implements consumer-driven contract testing for gRPC services.
+//
+// A consumer records the calls it makes to a provider, i.e., request and
+// response examples, using a Recorder and publishes them as a contract file.
+// The provider verifies the contract file using Verify, which replays the
+// recorded requests against an in-process server and checks the responses.
+//
+// By default, every field of a recorded response must be returned by the
+// provider with the same value. Fields with zero values are recorded too, so
+// a provider that starts setting a field the consumer got unset fails the
+// verification. Matchers relax this check for certain fields,
+// e.g., Like("user_id") only requires the provider to return a user ID.
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+)
+
+// File is a contract file between a consumer and a provider.
+type File struct {
+	Consumer     string         `json:"consumer"`
+	Provider     string         `json:"provider"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded RPC call and its expected outcome.
+type Interaction struct {
+	// Description describes the interaction, e.g., "authenticating a valid token".
+	Description string `json:"description,omitempty"`
+	// Method is the full RPC method string, i.e., /package.service/method.
+	Method string `json:"method"`
+	// Request is the request message in the protobuf JSON format.
+	Request json.RawMessage `json:"request"`
+	// Response is the expected response message in the protobuf JSON format.
+	// It is empty if the call is expected to fail.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Status is the expected status of the call. It is nil if the call is
+	// expected to succeed.
+	Status *Status `json:"status,omitempty"`
+	// Matchers relax the comparison of the expected and the actual response.
+	Matchers []Matcher `json:"matchers,omitempty"`
+}
+
+// Status is the status of a failed call. Only the status code is verified.
+type Status struct {
+	// Code is the name of the status code, e.g., NOT_FOUND.
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func newStatus(c codes.Code, msg string) *Status {
+	return &Status{Code: code.Code(c).String(), Message: msg}
+}
+
+func (s *Status) code() (codes.Code, error) {
+	if s == nil {
+		return codes.OK, nil
+	}
+	c, ok := code.Code_value[s.Code]
+	if !ok {
+		return codes.Unknown, fmt.Errorf("unknown status code %q", s.Code)
+	}
+	return codes.Code(c), nil
+}
+
+// MatcherType is the type of a Matcher.
+type MatcherType string
+
+const (
+	// MatchType matches values of the same JSON type.
+	MatchType MatcherType = "type"
+	// MatchRegex matches string values that match a regular expression.
+	MatchRegex MatcherType = "regex"
+)
+
+// Matcher relaxes the comparison of a response field.
+type Matcher struct {
+	// Path is the dot-separated path of the field in the response, using the
+	// proto field names, e.g., "note.note_id". The elements of repeated fields
+	// share the path of the field.
+	Path string      `json:"path"`
+	Type MatcherType `json:"type"`
+	// Regex is the regular expression of a MatchRegex matcher. It must match
+	// the whole value.
+	Regex string `json:"regex,omitempty"`
+}
+
+// Like returns a matcher that only requires the field at the given path, and
+// the fields nested in it, to have the same type as the recorded example.
+// Repeated fields must contain at least one element and all elements are
+// compared with the first recorded element.
+func Like(path string) Matcher {
+	return Matcher{Path: path, Type: MatchType}
+}
+
+// Term returns a matcher that requires the string field at the given path to
+// match the regular expression. All elements of a repeated field must match it.
+func Term(path, regex string) Matcher {
+	return Matcher{Path: path, Type: MatchRegex, Regex: regex}
+}
+
+// ReadFile reads a contract file.
+func ReadFile(name string) (*File, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	f := new(File)
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("pact: parsing %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// WriteFile writes the contract file.
+func (f *File) WriteFile(name string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(data, '\n'), 0644)
+}
@@ -0,0 +1,235 @@
+package pact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// notes serves the notes and fails for the others with codes.NotFound.
+type notes struct {
+	testpb.UnimplementedNotesServer
+	notes map[int32]*testpb.Note
+}
+
+func (s *notes) GetNote(ctx context.Context, in *testpb.GetNoteRequest) (*testpb.Note, error) {
+	note, ok := s.notes[in.NoteId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "note %d not found", in.NoteId)
+	}
+	return note, nil
+}
+
+func (s *notes) ListNotes(ctx context.Context, in *testpb.ListNotesRequest) (*testpb.ListNotesResponse, error) {
+	resp := new(testpb.ListNotesResponse)
+	for id := int32(1); len(resp.Notes) < len(s.notes); id++ {
+		resp.Notes = append(resp.Notes, s.notes[id])
+	}
+	return resp, nil
+}
+
+// consumerFile records the calls of a consumer to a notes server.
+func consumerFile(t *testing.T) *File {
+	r := NewRecorder("gateway", "notes")
+	provider := &notes{notes: map[int32]*testpb.Note{
+		1: {NoteId: 1, Title: "todo", Author: &testpb.Author{UserId: 7, Name: "user-7"}},
+		2: {NoteId: 2, Title: "done", Tags: []string{"a"}, Author: &testpb.Author{UserId: 8, Name: "user-8"}},
+	}}
+	cc := testservice.Serve(t, &testpb.Notes_ServiceDesc, provider, nil,
+		grpc.WithUnaryInterceptor(r.UnaryClientInterceptor()))
+	client := testpb.NewNotesClient(cc)
+
+	ctx := context.Background()
+	client.GetNote(Describe(ctx, "getting a note", Like("author.user_id"), Term("author.name", `user-\d+`)), &testpb.GetNoteRequest{NoteId: 1})
+	client.GetNote(Describe(ctx, "getting a missing note"), &testpb.GetNoteRequest{NoteId: 3})
+	client.ListNotes(Describe(ctx, "listing notes", Like("notes")), &testpb.ListNotesRequest{})
+
+	f, err := r.File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestRecorder(t *testing.T) {
+	f := consumerFile(t)
+	if f.Consumer != "gateway" || f.Provider != "notes" || len(f.Interactions) != 3 {
+		t.Fatalf("got contract file %+v", f)
+	}
+	in := f.Interactions[0]
+	if in.Description != "getting a note" || in.Method != "/contracts.test.Notes/GetNote" ||
+		!jsonEqual(in.Request, `{"note_id":1,"token":""}`) || in.Status != nil || len(in.Matchers) != 2 {
+		t.Errorf("got interaction %+v", in)
+	}
+	// The unpopulated fields are recorded, so that setting them is a mismatch.
+	if want := `{"note_id":1,"title":"todo","text":"","kind":"KIND_UNSPECIFIED","tags":[],"labels":{},"author":{"user_id":"7","name":"user-7"},"attachment":""}`; !jsonEqual(in.Response, want) {
+		t.Errorf("got response %s, want %s", in.Response, want)
+	}
+	if in := f.Interactions[1]; in.Response != nil || !reflect.DeepEqual(in.Status, &Status{Code: "NOT_FOUND", Message: "note 3 not found"}) {
+		t.Errorf("got failed interaction %+v with status %+v", in, in.Status)
+	}
+
+	name := filepath.Join(t.TempDir(), "pact.json")
+	if err := f.WriteFile(name); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The messages are indented in the file, so the files are compared in
+	// the compact JSON format.
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(f)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("read contract file %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+// jsonEqual returns true if data is the JSON encoding of the same value as want.
+func jsonEqual(data []byte, want string) bool {
+	var got, w interface{}
+	if json.Unmarshal(data, &got) != nil || json.Unmarshal([]byte(want), &w) != nil {
+		return false
+	}
+	return reflect.DeepEqual(got, w)
+}
+
+func TestRecorderError(t *testing.T) {
+	r := NewRecorder("gateway", "notes")
+	r.record(context.Background(), "/contracts.test.Notes/GetNote", "not a message", nil, nil)
+	if _, err := r.File(); err == nil {
+		t.Error("File() returned no error after a failed recording")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	f := consumerFile(t)
+	tests := []struct {
+		name     string
+		notes    map[int32]*testpb.Note
+		contract *contracts.ServiceContract
+		want     []string
+	}{
+		{
+			name: "verified",
+			notes: map[int32]*testpb.Note{
+				1: {NoteId: 1, Title: "todo", Author: &testpb.Author{UserId: 8, Name: "user-8"}},
+				2: {NoteId: 2, Title: "done", Tags: []string{"b", "c"}, Author: &testpb.Author{UserId: 9, Name: "admin"}},
+				3: {NoteId: 3, Title: "new", Author: &testpb.Author{UserId: 7, Name: "user-7"}},
+			},
+			want: []string{
+				"getting a note (/contracts.test.Notes/GetNote): ok",
+				"getting a missing note (/contracts.test.Notes/GetNote): status: expected NotFound, got <nil>",
+				"listing notes (/contracts.test.Notes/ListNotes): ok",
+			},
+		},
+		{
+			name: "mismatches",
+			notes: map[int32]*testpb.Note{
+				1: {NoteId: 1, Title: "to do", Author: &testpb.Author{Name: "admin"}},
+			},
+			want: []string{
+				`getting a note (/contracts.test.Notes/GetNote): author.name: admin does not match "user-\\d+"; title: expected todo, got to do`,
+				"getting a missing note (/contracts.test.Notes/GetNote): ok",
+				"listing notes (/contracts.test.Notes/ListNotes): ok",
+			},
+		},
+		{
+			// The recorded responses have the unpopulated fields, so that
+			// setting a field the consumer got as zero is a mismatch.
+			name: "zero fields set",
+			notes: map[int32]*testpb.Note{
+				1: {NoteId: 1, Title: "todo", Text: "text", Kind: testpb.Kind_KIND_LIST, Author: &testpb.Author{UserId: 7, Name: "user-7"}},
+				2: {NoteId: 2, Title: "done", Tags: []string{"a"}, Author: &testpb.Author{UserId: 8, Name: "user-8"}},
+			},
+			want: []string{
+				"getting a note (/contracts.test.Notes/GetNote): kind: expected KIND_UNSPECIFIED, got KIND_LIST; text: expected , got text",
+				"getting a missing note (/contracts.test.Notes/GetNote): ok",
+				"listing notes (/contracts.test.Notes/ListNotes): ok",
+			},
+		},
+		{
+			name: "violations",
+			notes: map[int32]*testpb.Note{
+				1: {NoteId: 1, Title: "todo", Author: &testpb.Author{UserId: 7, Name: "user-7"}},
+				2: {NoteId: 2, Title: "done", Tags: []string{"a"}, Author: &testpb.Author{UserId: 8, Name: "user-8"}},
+			},
+			contract: &contracts.ServiceContract{
+				ServiceName: "contracts.test.Notes",
+				RPCContracts: []*contracts.UnaryRPCContract{{
+					MethodName: "GetNote",
+					PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+						if respErr == nil && resp.Text == "" {
+							return errors.New("note has no text")
+						}
+						return nil
+					}},
+				}},
+			},
+			want: []string{
+				"getting a note (/contracts.test.Notes/GetNote): violation: note has no text",
+				"getting a missing note (/contracts.test.Notes/GetNote): ok",
+				"listing notes (/contracts.test.Notes/ListNotes): ok",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sc *contracts.ServerContract
+			if tt.contract != nil {
+				sc = contracts.NewServerContract(t.Log)
+				if err := sc.RegisterServiceContract(tt.contract); err != nil {
+					t.Fatal(err)
+				}
+			}
+			results, err := Verify(f, func(s *grpc.Server) {
+				testpb.RegisterNotesServer(s, &notes{notes: tt.notes})
+			}, sc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, res := range results {
+				got = append(got, res.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("results:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestVerifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *Interaction
+	}{
+		{name: "unknown method", in: &Interaction{Method: "/contracts.test.Notes/DeleteNote", Request: []byte(`{}`)}},
+		{name: "invalid request", in: &Interaction{Method: "/contracts.test.Notes/GetNote", Request: []byte(`{"id": 1}`)}},
+		{name: "unknown status", in: &Interaction{Method: "/contracts.test.Notes/GetNote", Request: []byte(`{}`), Status: &Status{Code: "GONE"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &File{Interactions: []*Interaction{tt.in}}
+			_, err := Verify(f, func(s *grpc.Server) {
+				testpb.RegisterNotesServer(s, &notes{})
+			}, nil)
+			if err == nil {
+				t.Error("Verify() returned no error")
+			}
+		})
+	}
+}
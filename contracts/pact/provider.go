@@ -0,0 +1,147 @@
+package pact
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/protoutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Result is the result of verifying an interaction.
+type Result struct {
+	Interaction *Interaction
+	// Mismatches describe the differences between the expected and the actual
+	// outcome of the call.
+	Mismatches []string
+	// Violations are the contract violations reported by the provider's
+	// ServerContract while serving the call.
+	Violations []*contracts.Violation
+}
+
+// Ok returns true if the interaction is verified.
+func (r *Result) Ok() bool {
+	return len(r.Mismatches) == 0 && len(r.Violations) == 0
+}
+
+func (r *Result) String() string {
+	name := r.Interaction.Method
+	if r.Interaction.Description != "" {
+		name = r.Interaction.Description + " (" + name + ")"
+	}
+	if r.Ok() {
+		return name + ": ok"
+	}
+	var problems []string
+	problems = append(problems, r.Mismatches...)
+	for _, v := range r.Violations {
+		problems = append(problems, "violation: "+v.Err.Error())
+	}
+	return name + ": " + strings.Join(problems, "; ")
+}
+
+// Verify replays the interactions of the contract file against an in-process
+// server and returns the result of each interaction. register registers the
+// provider's services to the server, e.g., pb.RegisterAuthServiceServer(s, impl).
+//
+// If sc is not nil, the server monitors sc and the contract violations
+// reported by sc while serving an interaction fail the interaction. The
+// provider's services must use sc.UnaryClientInterceptor for their downstream
+// calls for their call history conditions to be checked.
+//
+// The request and response types of the methods are looked up in the global
+// registry of protocol buffer types. Verify returns an error if the server
+// cannot be started or an interaction cannot be replayed.
+func Verify(f *File, register func(s *grpc.Server), sc *contracts.ServerContract) ([]*Result, error) {
+	var opts []grpc.ServerOption
+	var current *Result
+	var mu sync.Mutex
+	if sc != nil {
+		opts = append(opts, grpc.UnaryInterceptor(sc.UnaryServerInterceptor()))
+		remove := sc.AddReporter(contracts.ReporterFunc(func(v *contracts.Violation) {
+			mu.Lock()
+			defer mu.Unlock()
+			if current != nil {
+				current.Violations = append(current.Violations, v)
+			}
+		}))
+		defer remove()
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(opts...)
+	register(s)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///pact",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var results []*Result
+	for _, in := range f.Interactions {
+		res := &Result{Interaction: in}
+		mu.Lock()
+		current = res
+		mu.Unlock()
+		err := replay(conn, in, res)
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("pact: replaying %s: %v", in.Method, err)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func replay(conn *grpc.ClientConn, in *Interaction, res *Result) error {
+	md, err := protoutil.FindMethod(in.Method)
+	if err != nil {
+		return err
+	}
+	req := protoutil.NewMessage(md.Input())
+	if err := protojson.Unmarshal(in.Request, req); err != nil {
+		return fmt.Errorf("request: %v", err)
+	}
+	wantCode, err := in.Status.code()
+	if err != nil {
+		return err
+	}
+
+	resp := protoutil.NewMessage(md.Output())
+	callErr := conn.Invoke(context.Background(), in.Method, req, resp)
+	if gotCode := status.Code(callErr); gotCode != wantCode {
+		res.Mismatches = append(res.Mismatches, fmt.Sprintf("status: expected %s, got %v", wantCode, callErr))
+		return nil
+	}
+	if callErr != nil {
+		return nil
+	}
+
+	actual, err := marshalOptions.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	mismatches, err := compare(in.Response, actual, in.Matchers)
+	if err != nil {
+		return err
+	}
+	res.Mismatches = append(res.Mismatches, mismatches...)
+	return nil
+}
@@ -0,0 +1,92 @@
+package contracts
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Replay checks the contracts of sc against a recorded request, e.g., one
+// reported to a Collector, without serving it. The request is passed through
+// UnaryServerInterceptor and UnaryClientInterceptor as if it was served, but
+// the handler and the downstream calls return the recorded responses and
+// errors. The violations are reported as usual, so a Reporter can be used to
+// gather them. Replayed requests are not reported to the collector of sc.
+//
+// The recorded timings, deadlines and metadata of the calls are used for the
+// checks, if present. Replay is synchronous: all of the violations of the
+// request are reported before it returns.
+func (sc *ServerContract) Replay(report *CallReport) {
+	served := report.Call
+	ctx := context.WithValue(context.Background(), replayKey, served)
+	if served.RequestMetadata != nil {
+		ctx = metadata.NewIncomingContext(ctx, served.RequestMetadata)
+	}
+	if !served.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, served.Deadline)
+		defer cancel()
+	}
+
+	ids := make(map[string]bool)
+	for _, call := range report.Calls {
+		ids[call.ID] = true
+	}
+	children := make(map[string]CallSet)
+	var topLevel CallSet
+	for _, call := range report.Calls.Ordered() {
+		if call.Parent != nil && ids[call.Parent.ID] {
+			children[call.Parent.ID] = append(children[call.Parent.ID], call)
+		} else {
+			topLevel = append(topLevel, call)
+		}
+	}
+
+	r := &replayer{interceptor: sc.UnaryClientInterceptor(), children: children}
+	info := &grpc.UnaryServerInfo{FullMethod: served.FullMethod}
+	sc.UnaryServerInterceptor()(ctx, served.Request, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		for _, call := range topLevel {
+			r.replay(ctx, call)
+		}
+		return served.Response, served.Error
+	})
+}
+
+type replayer struct {
+	interceptor grpc.UnaryClientInterceptor
+	children    map[string]CallSet
+}
+
+// replay makes a recorded downstream call and its nested calls.
+func (r *replayer) replay(ctx context.Context, call *UnaryRPCCall) {
+	md := call.RequestMetadata.Copy()
+	delete(md, traceIDHeader)
+	delete(md, callIDHeader)
+	ctx = metadata.NewOutgoingContext(ctx, md)
+	ctx = context.WithValue(ctx, replayCallKey, call)
+	// The call gets its recorded deadline, or none, rather than one derived
+	// from the deadline of the served request, which could only be earlier.
+	ctx = context.WithoutCancel(ctx)
+	if !call.Deadline.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, call.Deadline)
+		defer cancel()
+	}
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, child := range r.children[call.ID] {
+			r.replay(ctx, child)
+		}
+		for _, opt := range opts {
+			switch o := opt.(type) {
+			case grpc.HeaderCallOption:
+				*o.HeaderAddr = call.Header
+			case grpc.TrailerCallOption:
+				*o.TrailerAddr = call.Trailer
+			}
+		}
+		return call.Error
+	}
+	r.interceptor(ctx, call.FullMethod, call.Request, call.Response, nil, invoker)
+}
@@ -0,0 +1,161 @@
+package contracts
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestReplayDeadlinePropagation(t *testing.T) {
+	served := time.Now().Add(time.Second)
+	tests := []struct {
+		name     string
+		deadline time.Time
+		want     []string
+	}{
+		{name: "propagated", deadline: served},
+		{name: "earlier", deadline: served.Add(-time.Millisecond)},
+		{name: "later", deadline: served.Add(time.Minute), want: []string{deadlineViolation}},
+		{name: "none", want: []string{deadlineViolation}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName:                frontService,
+				RequireDeadlinePropagation: true,
+			}})
+			sc.Replay(&CallReport{
+				Call: &UnaryRPCCall{
+					ID:         "served",
+					FullMethod: getMethod,
+					Request:    str("note"),
+					Response:   str("note"),
+					Deadline:   served,
+				},
+				Calls: CallSet{{
+					ID:         "call",
+					FullMethod: lookupMethod,
+					Request:    str("token"),
+					Response:   str("user"),
+					Deadline:   tt.deadline,
+				}},
+			})
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayTiming(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name string
+		end  time.Time
+		want []string
+	}{
+		{name: "fast", end: start.Add(10 * time.Millisecond)},
+		{name: "slow", end: start.Add(2 * time.Second), want: []string{latencyViolation}},
+		{name: "not recorded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lookup *UnaryRPCCall
+			svcContract := inspectHistory(func(calls RPCCallHistory) {
+				lookup, _ = calls.Filter(backService, "Lookup").First()
+			})
+			svcContract.RPCContracts[0].MaxLatency = time.Second
+			sc, v := newServerContract(t, []*ServiceContract{svcContract})
+			sc.Replay(&CallReport{
+				Call: &UnaryRPCCall{
+					ID:         "served",
+					FullMethod: getMethod,
+					Request:    str("note"),
+					Response:   str("note"),
+					StartTime:  start,
+					EndTime:    tt.end,
+				},
+				Calls: CallSet{{
+					ID:         "call",
+					FullMethod: lookupMethod,
+					Request:    str("token"),
+					Response:   str("user"),
+					StartTime:  start.Add(time.Millisecond),
+					EndTime:    start.Add(3 * time.Millisecond),
+					Duration:   2 * time.Millisecond,
+				}},
+			})
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			if lookup == nil || !lookup.StartTime.Equal(start.Add(time.Millisecond)) || lookup.Duration != 2*time.Millisecond {
+				t.Errorf("replayed call %+v does not have the recorded timing", lookup)
+			}
+		})
+	}
+}
+
+func TestReplayCalls(t *testing.T) {
+	var tree string
+	var lookup *UnaryRPCCall
+	svcContract := inspectHistory(func(calls RPCCallHistory) {
+		tree = dumpTree(calls.Tree())
+		lookup, _ = calls.Filter(backService, "Lookup").First()
+	})
+	svcContract.IncomingMetadata = &MetadataContract{Required: []string{"x-tenant-id"}}
+	svcContract.DownstreamMetadata = []*DownstreamMetadataContract{{
+		Target:           backService,
+		MetadataContract: MetadataContract{Required: []string{"x-request-id"}},
+	}}
+	sc, v := newServerContract(t, []*ServiceContract{svcContract})
+
+	served := &UnaryRPCCall{
+		ID:              "served",
+		FullMethod:      getMethod,
+		Request:         str("note"),
+		Response:        str("note"),
+		RequestMetadata: metadata.Pairs("x-tenant-id", "1"),
+	}
+	lookupCall := &UnaryRPCCall{
+		ID:              "lookup",
+		FullMethod:      lookupMethod,
+		Request:         str("token"),
+		Error:           status.Error(codes.NotFound, "no user"),
+		RequestMetadata: metadata.Pairs("x-request-id", "1", traceIDHeader, "trace", callIDHeader, "lookup"),
+		Header:          metadata.Pairs("server", "back"),
+		Trailer:         metadata.Pairs("cost", "2"),
+		Order:           0,
+	}
+	sc.Replay(&CallReport{
+		Call: served,
+		Calls: CallSet{
+			{ID: "read", FullMethod: readMethod, Request: str("token"), Response: str("user"), Parent: lookupCall, Order: 1},
+			lookupCall,
+			{ID: "other", FullMethod: readMethod, Request: str("token"), Response: str("user"), Order: 2},
+		},
+	})
+
+	// Only the Read calls lack the downstream metadata.
+	if got, want := v.names(), []string{metadataViolation, metadataViolation}; !slices.Equal(got, want) {
+		t.Errorf("violations = %v, want %v", got, want)
+	}
+	if tree != "Lookup(Read), Read" {
+		t.Errorf("replayed call tree = %q, want %q", tree, "Lookup(Read), Read")
+	}
+	if lookup == nil {
+		t.Fatal("Lookup call is not replayed")
+	}
+	if status.Code(lookup.Error) != codes.NotFound {
+		t.Errorf("replayed call error = %v, want the recorded error", lookup.Error)
+	}
+	if got := lookup.RequestMetadata; len(got.Get(traceIDHeader)) > 0 || len(got.Get(callIDHeader)) > 0 || got.Get("x-request-id")[0] != "1" {
+		t.Errorf("replayed call metadata = %v", got)
+	}
+	if lookup.Header.Get("server")[0] != "back" || lookup.Trailer.Get("cost")[0] != "2" {
+		t.Errorf("replayed call header = %v and trailer = %v, want the recorded ones", lookup.Header, lookup.Trailer)
+	}
+}
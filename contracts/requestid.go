@@ -11,6 +11,15 @@ type ctxKey int
 const (
 	// RequestIDKey is the request context key used to store the request ID.
 	RequestIDKey ctxKey = iota + 1
+	spanKey
+	parentKey
+	attemptsKey
+	deadlineKey
+	fullMethodKey
+	tableKey
+	replayKey
+	replayCallKey
+	uncheckedKey
 )
 
 func shortID() string {
@@ -1,5 +1,12 @@
 package contracts
 
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
 // UnaryRPCContract represents a contract for a unary RPC.
 type UnaryRPCContract struct {
 	// MethodName is the method name only, without the service name or package name.
@@ -12,6 +19,34 @@ type UnaryRPCContract struct {
 	// Each PostCondition should be a function with the following signature:
 	// `func(resp *Response, respErr error, req *Request, calls contracts.RPCCallHistory) error`.
 	PostConditions []Condition
+	// CallSequence is an optional pattern that describes the allowed sequences
+	// of downstream calls made by the RPC, e.g., `Authenticate (Read|ReadCached)+`.
+	// It is checked whenever the RPC returns without error. See SequencePattern
+	// for the pattern syntax.
+	CallSequence string
+	// AllowedCodes are the status codes that the RPC may return, besides
+	// codes.OK. If it is nil, any status code is allowed.
+	AllowedCodes []codes.Code
+	// StatusConditions are conditions that the status of the RPC must satisfy
+	// whenever the RPC returns an error, e.g., MessageMatches and HasDetail.
+	StatusConditions []StatusCondition
+	// MaxLatency is the maximum time the execution of a single call may take.
+	// Zero means no limit.
+	MaxLatency time.Duration
+	// LatencyObjectives are the latency objectives of the RPC, checked over a
+	// sliding window of calls.
+	LatencyObjectives []*LatencyObjective
+	// Idempotency marks the RPC as idempotent, if it is not nil.
+	Idempotency *IdempotencyContract
+	// IncomingMetadata is the contract on the metadata of the RPC requests.
+	IncomingMetadata *MetadataContract
+	// DownstreamMetadata are the contracts on the outgoing metadata of the
+	// downstream calls made by the RPC.
+	DownstreamMetadata []*DownstreamMetadataContract
+	// Sampler decides which requests of the RPC are checked, overriding the
+	// sampler of the ServerContract, see WithSampler. Latency objectives are
+	// checked over the sampled requests only.
+	Sampler Sampler
 }
 
 func (u *UnaryRPCContract) validate() error {
@@ -25,7 +60,50 @@ func (u *UnaryRPCContract) validate() error {
 			return err
 		}
 	}
-	return nil
+	for _, c := range u.StatusConditions {
+		if c == nil {
+			return errors.New("StatusCondition must not be nil")
+		}
+	}
+	for _, o := range u.LatencyObjectives {
+		if o == nil {
+			return errors.New("LatencyObjective must not be nil")
+		}
+		if err := o.validate(); err != nil {
+			return err
+		}
+	}
+	if u.Idempotency != nil {
+		if err := u.Idempotency.validate(); err != nil {
+			return err
+		}
+	}
+	return validateDownstreamMetadata(u.DownstreamMetadata)
+}
+
+// CheckPreConditions checks the preconditions of the contract against the
+// request and returns the errors of the violated preconditions.
+func (u *UnaryRPCContract) CheckPreConditions(req interface{}) []error {
+	var errs []error
+	for _, c := range u.PreConditions {
+		if err := invokePreCondition(c, req); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// CheckPostConditions checks the postconditions of the contract against the
+// outcome of a call and returns the errors of the violated postconditions.
+// The zero RPCCallHistory can be used for calls that made no downstream calls.
+func (u *UnaryRPCContract) CheckPostConditions(resp interface{}, respErr error, req interface{}, calls RPCCallHistory) []error {
+	var errs []error
+	for _, c := range u.PostConditions {
+		if err := invokePostCondition(c, resp, respErr, req, calls); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
 }
 
 // ServiceContract is a contract defined for a gRPC service.
@@ -34,6 +112,52 @@ type ServiceContract struct {
 	ServiceName string
 	// RPCContracts are the contracts defined for RPCs of the service.
 	RPCContracts []*UnaryRPCContract
+	// RequireDeadlinePropagation requires every downstream call made while
+	// serving an RPC of the service to have a deadline no later than the
+	// deadline of the served RPC. Only the calls made using a context derived
+	// from the request context are checked; calls made without it, e.g., using
+	// context.Background(), cannot be tied to the served RPC. Use
+	// WithRequestContext to report such calls.
+	RequireDeadlinePropagation bool
+	// IncomingMetadata is the contract on the metadata of the requests to all
+	// RPCs of the service.
+	IncomingMetadata *MetadataContract
+	// DownstreamMetadata are the contracts on the outgoing metadata of the
+	// downstream calls made by all RPCs of the service.
+	DownstreamMetadata []*DownstreamMetadataContract
+}
+
+func (s *ServiceContract) validate() error {
+	if s == nil {
+		return errors.New("ServiceContract must not be nil")
+	}
+	for _, rpcContract := range s.RPCContracts {
+		if rpcContract == nil {
+			return errors.New("UnaryRPCContract must not be nil")
+		}
+		if err := rpcContract.validate(); err != nil {
+			return err
+		}
+	}
+	return validateDownstreamMetadata(s.DownstreamMetadata)
+}
+
+// metadataContracts returns the metadata contracts of the service and its RPCs.
+func (s *ServiceContract) metadataContracts() []*MetadataContract {
+	var res []*MetadataContract
+	add := func(mc *MetadataContract, dcs []*DownstreamMetadataContract) {
+		if mc != nil {
+			res = append(res, mc)
+		}
+		for _, dc := range dcs {
+			res = append(res, &dc.MetadataContract)
+		}
+	}
+	add(s.IncomingMetadata, s.DownstreamMetadata)
+	for _, rpcContract := range s.RPCContracts {
+		add(rpcContract.IncomingMetadata, rpcContract.DownstreamMetadata)
+	}
+	return res
 }
 
 func getFullMethodName(serviceName string, methodName string) string {
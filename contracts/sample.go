@@ -0,0 +1,122 @@
+package contracts
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// Sampler decides which requests of the served RPCs are checked. The RPCs
+// of the requests that are not sampled are passed through without checking
+// their contracts or recording their downstream calls, which saves the cost
+// of checking on services with a high load.
+type Sampler interface {
+	// Sample reports whether the request of the served RPC with the given
+	// full method, i.e., /package.service/method, is checked. ctx is the
+	// request context. Sample may be called concurrently.
+	Sample(ctx context.Context, fullMethod string) bool
+}
+
+// SamplerFunc is an adapter to use an ordinary function as a Sampler.
+type SamplerFunc func(ctx context.Context, fullMethod string) bool
+
+// Sample calls f(ctx, fullMethod).
+func (f SamplerFunc) Sample(ctx context.Context, fullMethod string) bool {
+	return f(ctx, fullMethod)
+}
+
+// RateSampler returns a sampler that samples each request with the given
+// probability, e.g., 0.01 for one percent of the requests.
+func RateSampler(rate float64) Sampler {
+	return SamplerFunc(func(context.Context, string) bool {
+		return rand.Float64() < rate
+	})
+}
+
+type rateLimitSampler struct {
+	perSecond float64
+	burst     float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// RateLimitSampler returns a sampler that samples at most perSecond requests
+// per second on average, and at most burst requests at once. The limit is
+// shared by all of the RPCs that use the sampler. If perSecond or burst is
+// not positive, no request is sampled.
+func RateLimitSampler(perSecond float64, burst int) Sampler {
+	if perSecond <= 0 || burst <= 0 {
+		return SamplerFunc(func(context.Context, string) bool {
+			return false
+		})
+	}
+	return &rateLimitSampler{perSecond: perSecond, burst: float64(burst), tokens: float64(burst)}
+}
+
+func (s *rateLimitSampler) Sample(context.Context, string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if !s.last.IsZero() {
+		s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.perSecond)
+	}
+	s.last = now
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// MetadataSampler returns a sampler that samples the requests that carry the
+// given metadata key, e.g., a debug flag set by the caller.
+func MetadataSampler(key string) Sampler {
+	return SamplerFunc(func(ctx context.Context, _ string) bool {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return len(md.Get(key)) > 0
+	})
+}
+
+// AnySampler returns a sampler that samples the requests sampled by any of
+// the given samplers, e.g., the requests that carry a debug flag and one
+// percent of the other requests:
+//
+//	contracts.AnySampler(contracts.MetadataSampler("x-debug"), contracts.RateSampler(0.01))
+//
+// The samplers are consulted in order until one of them samples the request.
+func AnySampler(samplers ...Sampler) Sampler {
+	return SamplerFunc(func(ctx context.Context, fullMethod string) bool {
+		for _, s := range samplers {
+			if s.Sample(ctx, fullMethod) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// sampled reports whether a request of a served RPC is checked. The sampler
+// of the RPC contract takes precedence over the sampler of sc. See also
+// WithCompleteCallTrees.
+func (sc *ServerContract) sampled(ctx context.Context, t *contractTable, fullMethod string) bool {
+	sampler := sc.sampler
+	if c, ok := t.unaryRPCContracts[fullMethod]; ok && c.Sampler != nil {
+		sampler = c.Sampler
+	}
+	if sampler == nil {
+		return true
+	}
+	if sc.collector != nil && sc.completeCallTrees && (sc.trustedCaller == nil || sc.trustedCaller(ctx)) {
+		if md, _ := metadata.FromIncomingContext(ctx); len(md.Get(callIDHeader)) > 0 {
+			return true
+		}
+	}
+	return sampler.Sample(ctx, fullMethod)
+}
@@ -0,0 +1,237 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRateSampler(t *testing.T) {
+	tests := []struct {
+		rate     float64
+		min, max int
+	}{
+		{rate: 0, min: 0, max: 0},
+		{rate: 1, min: 1000, max: 1000},
+		{rate: 0.5, min: 400, max: 600},
+	}
+	for _, tt := range tests {
+		s := RateSampler(tt.rate)
+		sampled := 0
+		for i := 0; i < 1000; i++ {
+			if s.Sample(context.Background(), getMethod) {
+				sampled++
+			}
+		}
+		if sampled < tt.min || sampled > tt.max {
+			t.Errorf("RateSampler(%g) sampled %d of 1000 requests, want [%d, %d]", tt.rate, sampled, tt.min, tt.max)
+		}
+	}
+}
+
+func TestRateLimitSampler(t *testing.T) {
+	tests := []struct {
+		name      string
+		perSecond float64
+		burst     int
+		elapsed   time.Duration
+		want      int
+	}{
+		{name: "burst", perSecond: 3, burst: 3, want: 3},
+		{name: "burst smaller than rate", perSecond: 3, burst: 1, want: 1},
+		{name: "burst larger than rate", perSecond: 0.5, burst: 4, want: 4},
+		{name: "refilled", perSecond: 3, burst: 3, elapsed: 2 * time.Second / 3, want: 5},
+		{name: "refilled up to burst", perSecond: 3, burst: 3, elapsed: time.Hour, want: 6},
+		{name: "partly refilled", perSecond: 0.5, burst: 1, elapsed: time.Second, want: 1},
+		{name: "fractional rate", perSecond: 0.5, burst: 1, elapsed: 2 * time.Second, want: 2},
+		{name: "zero rate", perSecond: 0, burst: 3, elapsed: time.Hour},
+		{name: "negative rate", perSecond: -1, burst: 3, elapsed: time.Hour},
+		{name: "zero burst", perSecond: 3, burst: 0, elapsed: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := RateLimitSampler(tt.perSecond, tt.burst)
+			sampled := 0
+			for i := 0; i < 10; i++ {
+				if s.Sample(context.Background(), getMethod) {
+					sampled++
+				}
+			}
+			if tt.elapsed > 0 {
+				if rl, ok := s.(*rateLimitSampler); ok {
+					rl.last = rl.last.Add(-tt.elapsed)
+				}
+				for i := 0; i < 10; i++ {
+					if s.Sample(context.Background(), getMethod) {
+						sampled++
+					}
+				}
+			}
+			if sampled != tt.want {
+				t.Errorf("sampled %d requests, want %d", sampled, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataSampler(t *testing.T) {
+	s := MetadataSampler("x-debug")
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want bool
+	}{
+		{name: "flag", md: metadata.Pairs("x-debug", "1"), want: true},
+		{name: "empty flag", md: metadata.Pairs("x-debug", ""), want: true},
+		{name: "other key", md: metadata.Pairs("x-trace", "1")},
+		{name: "no metadata"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			if got := s.Sample(ctx, getMethod); got != tt.want {
+				t.Errorf("Sample() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnySampler(t *testing.T) {
+	var consulted []string
+	sampler := func(name string, sample bool) Sampler {
+		return SamplerFunc(func(ctx context.Context, fullMethod string) bool {
+			if fullMethod != getMethod {
+				t.Errorf("sampler %s got full method %s", name, fullMethod)
+			}
+			consulted = append(consulted, name)
+			return sample
+		})
+	}
+	tests := []struct {
+		name      string
+		samplers  []Sampler
+		want      bool
+		consulted []string
+	}{
+		{name: "none"},
+		{name: "first", samplers: []Sampler{sampler("a", true), sampler("b", true)}, want: true, consulted: []string{"a"}},
+		{name: "second", samplers: []Sampler{sampler("a", false), sampler("b", true)}, want: true, consulted: []string{"a", "b"}},
+		{name: "neither", samplers: []Sampler{sampler("a", false), sampler("b", false)}, consulted: []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consulted = nil
+			if got := AnySampler(tt.samplers...).Sample(context.Background(), getMethod); got != tt.want {
+				t.Errorf("Sample() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(consulted, tt.consulted) {
+				t.Errorf("consulted %v, want %v", consulted, tt.consulted)
+			}
+		})
+	}
+}
+
+func TestSampling(t *testing.T) {
+	never, always := RateSampler(0), RateSampler(1)
+	trusted := func(ctx context.Context) bool {
+		md, _ := metadata.FromIncomingContext(ctx)
+		return len(md.Get("x-trusted")) > 0
+	}
+	tests := []struct {
+		name     string
+		opts     []ServerOption
+		contract Sampler
+		md       []string
+		checked  bool
+	}{
+		{name: "no sampler", checked: true},
+		{name: "not sampled", opts: []ServerOption{WithSampler(never)}},
+		{name: "sampled", opts: []ServerOption{WithSampler(always)}, checked: true},
+		{name: "contract sampler", opts: []ServerOption{WithSampler(never)}, contract: always, checked: true},
+		{name: "contract sampler overrides", opts: []ServerOption{WithSampler(always)}, contract: never},
+		{
+			name:     "debug flag",
+			opts:     []ServerOption{WithSampler(never)},
+			contract: AnySampler(MetadataSampler("x-debug"), never),
+			md:       []string{"x-debug", "1"},
+			checked:  true,
+		},
+		{name: "no debug flag", opts: []ServerOption{WithSampler(never)}, contract: MetadataSampler("x-debug")},
+		{
+			name:    "complete call trees",
+			opts:    []ServerOption{WithSampler(never), WithCompleteCallTrees()},
+			md:      []string{callIDHeader, "call"},
+			checked: true,
+		},
+		{
+			name:    "complete call trees of trusted callers",
+			opts:    []ServerOption{WithSampler(never), WithCompleteCallTrees(), WithTrustedCallers(trusted)},
+			md:      []string{callIDHeader, "call", "x-trusted", "1"},
+			checked: true,
+		},
+		{
+			name: "complete call trees of untrusted callers",
+			opts: []ServerOption{WithSampler(never), WithCompleteCallTrees(), WithTrustedCallers(trusted)},
+			md:   []string{callIDHeader, "call"},
+		},
+		{name: "complete call trees of root requests", opts: []ServerOption{WithSampler(never), WithCompleteCallTrees()}},
+		{name: "incomplete call trees", opts: []ServerOption{WithSampler(never)}, md: []string{callIDHeader, "call"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := new(reports)
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName: frontService,
+				RPCContracts: []*UnaryRPCContract{{
+					MethodName: "Get",
+					PreConditions: []Condition{Named("nonempty", func(req *wrapperspb.StringValue) error {
+						if req.GetValue() == "" {
+							return errors.New("empty request")
+						}
+						return nil
+					})},
+					Sampler: tt.contract,
+				}},
+			}}, append([]ServerOption{WithCollector(col)}, tt.opts...)...)
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.AppendToOutgoingContext(ctx, tt.md...)
+			}
+			if _, err := call(ctx, frontCC, getMethod, ""); err != nil {
+				t.Fatal(err)
+			}
+
+			var want []string
+			if tt.checked {
+				want = []string{"nonempty"}
+			}
+			if got := v.names(); !slices.Equal(got, want) {
+				t.Errorf("violations = %v, want %v", got, want)
+			}
+			// The downstream calls of the requests that are not checked are not
+			// recorded either.
+			col.mu.Lock()
+			defer col.mu.Unlock()
+			if tt.checked != (len(col.reports) == 1) {
+				t.Fatalf("got %d reports, want one iff checked", len(col.reports))
+			}
+			if tt.checked && col.reports[0].Calls.Count() != 1 {
+				t.Errorf("recorded %d downstream calls, want 1", col.reports[0].Calls.Count())
+			}
+		})
+	}
+}
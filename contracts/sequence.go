@@ -0,0 +1,275 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SequencePattern is a compiled pattern that describes the allowed sequences
+// of RPC calls, e.g., `Authenticate (GetQuota)? (Read|ReadCached)+ Audit`.
+//
+// A pattern is a regular expression over method names. Method names are
+// separated by spaces and can be combined using alternation (|), grouping
+// with parentheses, and the ? (zero or one), * (zero or more) and + (one or
+// more) operators. A name without a slash matches calls to the method with
+// that name in any service, while a name in the form of package.service/method
+// only matches calls to that method of the service.
+type SequencePattern struct {
+	expr  string
+	start *seqState
+}
+
+type seqStateKind int
+
+const (
+	seqName seqStateKind = iota
+	seqSplit
+	seqAccept
+)
+
+// seqState is a state of the nondeterministic finite automaton of a pattern.
+type seqState struct {
+	kind seqStateKind
+	name string
+	out  *seqState
+	out1 *seqState
+}
+
+// seqFrag is a partially built automaton with dangling out pointers.
+type seqFrag struct {
+	start *seqState
+	outs  []**seqState
+}
+
+func (f seqFrag) patch(s *seqState) {
+	for _, out := range f.outs {
+		*out = s
+	}
+}
+
+// CompileSequence parses a sequence pattern.
+func CompileSequence(expr string) (*SequencePattern, error) {
+	p := &seqParser{tokens: tokenizeSequence(expr)}
+	frag, err := p.alt()
+	if err != nil {
+		return nil, fmt.Errorf("invalid sequence pattern %q: %v", expr, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid sequence pattern %q: unexpected %q", expr, p.tokens[p.pos])
+	}
+	frag.patch(&seqState{kind: seqAccept})
+	return &SequencePattern{expr: expr, start: frag.start}, nil
+}
+
+// MustCompileSequence is like CompileSequence but panics if the pattern cannot be parsed.
+func MustCompileSequence(expr string) *SequencePattern {
+	p, err := CompileSequence(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the source text of the pattern.
+func (p *SequencePattern) String() string {
+	return p.expr
+}
+
+// Match checks the calls, in invocation order, against the pattern. The
+// returned error reports the first call where the sequence differs from the pattern.
+func (p *SequencePattern) Match(cs CallSet) error {
+	calls := cs.Expect().calls
+	current := p.closure([]*seqState{p.start})
+	for i, call := range calls {
+		var next []*seqState
+		for _, s := range current {
+			if s.kind == seqName && matchSequenceName(call.FullMethod, s.name) {
+				next = append(next, s.out)
+			}
+		}
+		if len(next) == 0 {
+			return fmt.Errorf("call #%d to %s does not match sequence pattern %q, expected %s; calls: %s",
+				i, strings.TrimPrefix(call.FullMethod, "/"), p.expr, expected(current), Expectation{calls}.sequence())
+		}
+		current = p.closure(next)
+	}
+	for _, s := range current {
+		if s.kind == seqAccept {
+			return nil
+		}
+	}
+	return fmt.Errorf("calls ended before matching sequence pattern %q, expected %s; calls: %s",
+		p.expr, expected(current), Expectation{calls}.sequence())
+}
+
+// closure returns the states reachable from the given states without consuming a call.
+func (p *SequencePattern) closure(states []*seqState) []*seqState {
+	seen := make(map[*seqState]bool)
+	var res []*seqState
+	var add func(s *seqState)
+	add = func(s *seqState) {
+		if seen[s] {
+			return
+		}
+		seen[s] = true
+		if s.kind == seqSplit {
+			add(s.out)
+			add(s.out1)
+			return
+		}
+		res = append(res, s)
+	}
+	for _, s := range states {
+		add(s)
+	}
+	return res
+}
+
+func expected(states []*seqState) string {
+	names := make(map[string]bool)
+	for _, s := range states {
+		switch s.kind {
+		case seqName:
+			names[s.name] = true
+		case seqAccept:
+			names["end of calls"] = true
+		}
+	}
+	var res []string
+	for name := range names {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return strings.Join(res, " or ")
+}
+
+func matchSequenceName(fullMethod, name string) bool {
+	if strings.Contains(name, "/") {
+		return matchMethod(fullMethod, name)
+	}
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:] == name
+}
+
+func tokenizeSequence(expr string) []string {
+	var tokens []string
+	name := ""
+	for _, r := range expr {
+		switch {
+		case strings.ContainsRune("()|?*+", r):
+			if name != "" {
+				tokens = append(tokens, name)
+				name = ""
+			}
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			if name != "" {
+				tokens = append(tokens, name)
+				name = ""
+			}
+		default:
+			name += string(r)
+		}
+	}
+	if name != "" {
+		tokens = append(tokens, name)
+	}
+	return tokens
+}
+
+type seqParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *seqParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// alt parses `seq ('|' seq)*`.
+func (p *seqParser) alt() (seqFrag, error) {
+	frag, err := p.seq()
+	if err != nil {
+		return seqFrag{}, err
+	}
+	for p.peek() == "|" {
+		p.pos++
+		other, err := p.seq()
+		if err != nil {
+			return seqFrag{}, err
+		}
+		s := &seqState{kind: seqSplit, out: frag.start, out1: other.start}
+		frag = seqFrag{start: s, outs: append(frag.outs, other.outs...)}
+	}
+	return frag, nil
+}
+
+// seq parses a possibly empty sequence of repeated atoms.
+func (p *seqParser) seq() (seqFrag, error) {
+	// An empty sequence is a split state whose both branches are patched together.
+	empty := &seqState{kind: seqSplit}
+	frag := seqFrag{start: empty, outs: []**seqState{&empty.out, &empty.out1}}
+	for tok := p.peek(); tok != "" && tok != "|" && tok != ")"; tok = p.peek() {
+		next, err := p.rep()
+		if err != nil {
+			return seqFrag{}, err
+		}
+		frag.patch(next.start)
+		frag.outs = next.outs
+	}
+	return frag, nil
+}
+
+// rep parses `atom ('?' | '*' | '+')*`.
+func (p *seqParser) rep() (seqFrag, error) {
+	frag, err := p.atom()
+	if err != nil {
+		return seqFrag{}, err
+	}
+	for {
+		switch p.peek() {
+		case "?":
+			s := &seqState{kind: seqSplit, out: frag.start}
+			frag = seqFrag{start: s, outs: append(frag.outs, &s.out1)}
+		case "*":
+			s := &seqState{kind: seqSplit, out: frag.start}
+			frag.patch(s)
+			frag = seqFrag{start: s, outs: []**seqState{&s.out1}}
+		case "+":
+			s := &seqState{kind: seqSplit, out: frag.start}
+			frag.patch(s)
+			frag = seqFrag{start: frag.start, outs: []**seqState{&s.out1}}
+		default:
+			return frag, nil
+		}
+		p.pos++
+	}
+}
+
+// atom parses a method name or a parenthesized pattern.
+func (p *seqParser) atom() (seqFrag, error) {
+	tok := p.peek()
+	switch tok {
+	case "(":
+		p.pos++
+		frag, err := p.alt()
+		if err != nil {
+			return seqFrag{}, err
+		}
+		if p.peek() != ")" {
+			return seqFrag{}, errors.New("missing )")
+		}
+		p.pos++
+		return frag, nil
+	case "?", "*", "+":
+		return seqFrag{}, fmt.Errorf("missing operand for %s", tok)
+	}
+	p.pos++
+	s := &seqState{kind: seqName, name: tok}
+	return seqFrag{start: s, outs: []**seqState{&s.out}}, nil
+}
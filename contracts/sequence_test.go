@@ -0,0 +1,150 @@
+package contracts
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSequencePatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		calls   []string
+		want    bool
+	}{
+		{"Lookup", []string{"Lookup"}, true},
+		{"Lookup", nil, false},
+		{"Lookup", []string{"Lookup", "Lookup"}, false},
+		{"Lookup Read", []string{"Lookup", "Read"}, true},
+		{"Lookup Read", []string{"Read", "Lookup"}, false},
+		{"Lookup|Read", []string{"Read"}, true},
+		{"Lookup|Read", []string{"Audit"}, false},
+		{"Lookup?", nil, true},
+		{"Lookup?", []string{"Lookup"}, true},
+		{"Lookup?", []string{"Lookup", "Lookup"}, false},
+		{"Lookup*", nil, true},
+		{"Lookup*", []string{"Lookup", "Lookup", "Lookup"}, true},
+		{"Lookup+", nil, false},
+		{"Lookup+", []string{"Lookup", "Lookup"}, true},
+		{"Lookup (Read|Audit)+", []string{"Lookup", "Read", "Audit", "Read"}, true},
+		{"Lookup (Read|Audit)+", []string{"Lookup"}, false},
+		{"(Lookup Read)*", []string{"Lookup", "Read", "Lookup", "Read"}, true},
+		{"(Lookup Read)*", []string{"Lookup", "Read", "Lookup"}, false},
+		{"(Lookup*)*", []string{"Lookup", "Lookup"}, true},
+		{"(Lookup?)+ Read", []string{"Read"}, true},
+		{"()", nil, true},
+		{"", nil, true},
+		{"", []string{"Lookup"}, false},
+		{"Lookup | ", nil, true},
+		{"test.Back/Lookup", []string{"Lookup"}, true},
+		{"/test.Back/Lookup", []string{"Lookup"}, true},
+		{"test.Front/Lookup", []string{"Lookup"}, false},
+		{"Lookup??", []string{"Lookup"}, true},
+	}
+	for _, tt := range tests {
+		p, err := CompileSequence(tt.pattern)
+		if err != nil {
+			t.Errorf("CompileSequence(%q) failed: %v", tt.pattern, err)
+			continue
+		}
+		if err := p.Match(callSet(tt.calls...)); (err == nil) != tt.want {
+			t.Errorf("pattern %q matching %v = %v, want match %v", tt.pattern, tt.calls, err, tt.want)
+		}
+	}
+}
+
+func TestSequencePatternErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"(Lookup", "missing )"},
+		{"Lookup)", `unexpected ")"`},
+		{"* Lookup", "missing operand for *"},
+		{"Lookup (+)", "missing operand for +"},
+		{"(|?)", "missing operand for ?"},
+	}
+	for _, tt := range tests {
+		_, err := CompileSequence(tt.pattern)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("CompileSequence(%q) = %v, want error containing %q", tt.pattern, err, tt.want)
+		}
+	}
+}
+
+func TestSequencePatternMatchError(t *testing.T) {
+	p := MustCompileSequence("Lookup (Read|Audit)+")
+	tests := []struct {
+		calls []string
+		want  string
+	}{
+		{[]string{"Read"}, "call #0 to test.Back/Read does not match sequence pattern \"Lookup (Read|Audit)+\", expected Lookup"},
+		{[]string{"Lookup", "Lookup"}, "call #1 to test.Back/Lookup does not match sequence pattern \"Lookup (Read|Audit)+\", expected Audit or Read"},
+		{[]string{"Lookup"}, "calls ended before matching sequence pattern \"Lookup (Read|Audit)+\", expected Audit or Read; calls: [test.Back/Lookup]"},
+		{[]string{"Lookup", "Read", "Lookup"}, "expected Audit or Read or end of calls"},
+	}
+	for _, tt := range tests {
+		err := p.Match(callSet(tt.calls...))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Match(%v) = %v, want error containing %q", tt.calls, err, tt.want)
+		}
+	}
+	if p.String() != "Lookup (Read|Audit)+" {
+		t.Errorf("String() = %q", p.String())
+	}
+}
+
+func TestCallSequenceContract(t *testing.T) {
+	tests := []struct {
+		name    string
+		calls   []string
+		handErr bool
+		want    []string
+	}{
+		{name: "matching", calls: []string{lookupMethod, readMethod, readMethod}},
+		{
+			name:  "not matching",
+			calls: []string{readMethod},
+			want:  []string{`call #0 to test.Back/Read does not match sequence pattern "Lookup Read+", expected Lookup; calls: [test.Back/Read]`},
+		},
+		{name: "failed", calls: []string{readMethod}, handErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName:  frontService,
+				RPCContracts: []*UnaryRPCContract{{MethodName: "Get", CallSequence: "Lookup Read+"}},
+			}})
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				for _, method := range tt.calls {
+					if _, err := call(ctx, backCC, method, in.Value); err != nil {
+						return nil, err
+					}
+				}
+				if tt.handErr {
+					return nil, context.Canceled
+				}
+				return in, nil
+			})
+			call(context.Background(), frontCC, getMethod, "note")
+			if got := v.errors(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallSequenceContractInvalid(t *testing.T) {
+	sc := NewServerContract(t.Log)
+	err := sc.RegisterServiceContract(&ServiceContract{
+		ServiceName:  frontService,
+		RPCContracts: []*UnaryRPCContract{{MethodName: "Get", CallSequence: "Lookup ("}},
+	})
+	if err == nil {
+		t.Error("RegisterServiceContract accepted an invalid call sequence")
+	}
+}
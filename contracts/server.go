@@ -3,9 +3,16 @@ package contracts
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 // LogFunc is a function that logs the provided message. It is compatible
@@ -14,68 +21,223 @@ type LogFunc func(args ...interface{})
 
 // ServerContract is a contract defined for a gRPC server.
 type ServerContract struct {
-	logFunc LogFunc
+	logFunc        LogFunc
+	collector      Collector
+	reports        reportQueue
+	trustedCaller  func(ctx context.Context) bool
+	snapshots      bool
+	requestContext bool
+	validator      Validator
+	sampler        Sampler
+	reporters      reporters
+	control        control
+
+	// completeCallTrees makes the requests of traced downstream calls bypass sampling.
+	completeCallTrees bool
 
 	callsLock     sync.RWMutex
 	unaryRPCCalls map[string]map[string][]*UnaryRPCCall
 	callCnt       map[string]int
+	callsByID     map[string]*UnaryRPCCall
+	// inflight holds the downstream calls that have not completed yet. They
+	// are added to the call history once they complete.
+	inflight map[string]*UnaryRPCCall
 
-	contractsLock     sync.Mutex
-	unaryRPCContracts map[string]*UnaryRPCContract
-	serve             bool
+	// contractsLock serializes the updates of contracts.
+	contractsLock sync.Mutex
+	contracts     atomic.Pointer[contractTable]
 }
 
 // NewServerContract creates a ServerContract that has no contracts registered.
 // It requires a logger function to log the violation of its contracts.
-func NewServerContract(logFunc LogFunc) *ServerContract {
-	return &ServerContract{
-		logFunc:           logFunc,
-		unaryRPCCalls:     make(map[string]map[string][]*UnaryRPCCall),
-		callCnt:           make(map[string]int),
+func NewServerContract(logFunc LogFunc, opts ...ServerOption) *ServerContract {
+	sc := &ServerContract{
+		logFunc:       logFunc,
+		unaryRPCCalls: make(map[string]map[string][]*UnaryRPCCall),
+		callCnt:       make(map[string]int),
+		callsByID:     make(map[string]*UnaryRPCCall),
+		inflight:      make(map[string]*UnaryRPCCall),
+		reports:       reportQueue{size: DefaultReportQueueSize, timeout: DefaultReportTimeout},
+	}
+	sc.contracts.Store(new(contractTable))
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
+}
+
+// contractTable is a set of registered contracts. It is never modified once
+// it is stored in a ServerContract; updates replace the whole table, so that
+// the interceptors can read it without locking.
+//
+// The parts of the contracts that need preparation, e.g., patterns, are
+// compiled into the table when the contracts are registered, so that the
+// registered contracts are never modified.
+type contractTable struct {
+	unaryRPCContracts map[string]*UnaryRPCContract
+	serviceContracts  map[string][]*ServiceContract
+	rpcs              map[string]*rpcState
+	metadata          map[*MetadataContract]*metadataMatcher
+}
+
+// rpcState is the state kept by a contract table for a registered RPC contract.
+type rpcState struct {
+	callSequence *SequencePattern
+	latency      []*latencyWindow
+	idempotency  *idempotencyCache
+}
+
+// without returns a copy of the table without the contracts of the given services.
+func (t *contractTable) without(serviceNames map[string]bool) *contractTable {
+	res := &contractTable{
 		unaryRPCContracts: make(map[string]*UnaryRPCContract),
+		serviceContracts:  make(map[string][]*ServiceContract),
+		rpcs:              make(map[string]*rpcState),
+		metadata:          make(map[*MetadataContract]*metadataMatcher),
 	}
+	for fullMethod, c := range t.unaryRPCContracts {
+		if !serviceNames[serviceName(fullMethod)] {
+			res.unaryRPCContracts[fullMethod] = c
+			res.rpcs[fullMethod] = t.rpcs[fullMethod]
+		}
+	}
+	for name, svcContracts := range t.serviceContracts {
+		if serviceNames[name] {
+			continue
+		}
+		res.serviceContracts[name] = svcContracts
+		for _, svcContract := range svcContracts {
+			for _, mc := range svcContract.metadataContracts() {
+				res.metadata[mc] = t.metadata[mc]
+			}
+		}
+	}
+	return res
 }
 
-// RegisterServiceContract registers a service contract and its RPC contracts to
-// the gRPC server contract. This must be called before invoking UnaryServerInterceptor.
-func (sc *ServerContract) RegisterServiceContract(svcContract *ServiceContract) error {
+// add compiles a service contract and its RPC contracts and adds them to the table.
+func (t *contractTable) add(svcContract *ServiceContract) error {
 	for _, rpcContract := range svcContract.RPCContracts {
-		if err := rpcContract.validate(); err != nil {
+		fullMethodName := getFullMethodName(svcContract.ServiceName, rpcContract.MethodName)
+		if _, ok := t.unaryRPCContracts[fullMethodName]; ok {
+			return errors.New("ServerContract.RegisterServiceContract found duplicate contract registration")
+		}
+		state := new(rpcState)
+		if rpcContract.CallSequence != "" {
+			p, err := CompileSequence(rpcContract.CallSequence)
+			if err != nil {
+				return err
+			}
+			state.callSequence = p
+		}
+		for _, o := range rpcContract.LatencyObjectives {
+			state.latency = append(state.latency, newLatencyWindow(o))
+		}
+		if rpcContract.Idempotency != nil {
+			state.idempotency = newIdempotencyCache(rpcContract.Idempotency)
+		}
+		t.unaryRPCContracts[fullMethodName] = rpcContract
+		t.rpcs[fullMethodName] = state
+	}
+	for _, mc := range svcContract.metadataContracts() {
+		m, err := compileMetadata(mc)
+		if err != nil {
 			return err
 		}
+		t.metadata[mc] = m
+	}
+	svcContracts := t.serviceContracts[svcContract.ServiceName]
+	t.serviceContracts[svcContract.ServiceName] = append(svcContracts[:len(svcContracts):len(svcContracts)], svcContract)
+	return nil
+}
+
+// table returns the current contracts of sc.
+func (sc *ServerContract) table() *contractTable {
+	return sc.contracts.Load()
+}
+
+// RegisterServiceContract registers a service contract and its RPC contracts to
+// the gRPC server contract. It may be called while serving RPCs; the RPCs
+// served afterwards are checked against the new contract.
+func (sc *ServerContract) RegisterServiceContract(svcContract *ServiceContract) error {
+	if err := svcContract.validate(); err != nil {
+		return err
 	}
-	return sc.register(svcContract)
+	return sc.update(nil, svcContract)
 }
 
-func (sc *ServerContract) register(svcContract *ServiceContract) error {
+// UnregisterServiceContract removes all of the contracts registered for a
+// service, i.e., package.service, and the counters of their conditions. The
+// RPCs in flight are still checked against the removed contracts.
+func (sc *ServerContract) UnregisterServiceContract(serviceName string) error {
 	sc.contractsLock.Lock()
 	defer sc.contractsLock.Unlock()
 
-	if sc.serve {
-		return errors.New("ServerContract.RegisterServiceContract must called before ServerContract.UnaryServerInterceptor")
+	t := sc.table()
+	if _, ok := t.serviceContracts[serviceName]; !ok {
+		return errors.New("ServerContract.UnregisterServiceContract found no contract registered for " + serviceName)
 	}
+	sc.contracts.Store(t.without(map[string]bool{serviceName: true}))
+	sc.resetCounts(serviceName)
+	return nil
+}
 
-	for _, rpcContract := range svcContract.RPCContracts {
-		fullMethodName := getFullMethodName(svcContract.ServiceName, rpcContract.MethodName)
-		if _, ok := sc.unaryRPCContracts[fullMethodName]; ok {
-			return errors.New("ServerContract.RegisterServiceContract found duplicate contract registration")
+// ReplaceServiceContract replaces the contracts registered for the services
+// of the given service contracts, if any, with them. All of the contracts are
+// replaced at once: every RPC is checked either against the old contracts or
+// against the new ones. If a contract is invalid, nothing is replaced. The
+// counters of the conditions of the replaced services are reset, as in
+// UnregisterServiceContract. It can be used to reload contracts while serving
+// RPCs, e.g., when a contract file changes.
+func (sc *ServerContract) ReplaceServiceContract(svcContracts ...*ServiceContract) error {
+	serviceNames := make(map[string]bool)
+	for _, svcContract := range svcContracts {
+		if err := svcContract.validate(); err != nil {
+			return err
 		}
-		sc.unaryRPCContracts[fullMethodName] = rpcContract
+		serviceNames[svcContract.ServiceName] = true
+	}
+	return sc.update(serviceNames, svcContracts...)
+}
+
+// update replaces the contract table of sc with a copy that has the contracts
+// of the removed services removed and the added contracts added. The counters
+// of the removed services are reset.
+func (sc *ServerContract) update(removed map[string]bool, added ...*ServiceContract) error {
+	sc.contractsLock.Lock()
+	defer sc.contractsLock.Unlock()
+
+	t := sc.table().without(removed)
+	for _, svcContract := range added {
+		if err := t.add(svcContract); err != nil {
+			return err
+		}
+	}
+	sc.contracts.Store(t)
+	for name := range removed {
+		sc.resetCounts(name)
 	}
 	return nil
 }
 
+// RPCContract returns the contract registered for the given full method, i.e.,
+// /package.service/method. It returns nil if there is no such contract.
+func (sc *ServerContract) RPCContract(fullMethod string) *UnaryRPCContract {
+	return sc.table().unaryRPCContracts[fullMethod]
+}
+
 func (sc *ServerContract) generateRequestID(ctx context.Context) (context.Context, string) {
-	sc.callsLock.RLock()
-	defer sc.callsLock.RUnlock()
+	sc.callsLock.Lock()
+	defer sc.callsLock.Unlock()
 
 	var requestID string
 	for {
 		requestID = shortID()
-		if _, ok := sc.unaryRPCCalls[requestID]; !ok {
+		if _, ok := sc.callCnt[requestID]; !ok {
 			break
 		}
 	}
+	sc.callCnt[requestID] = 0
 	return context.WithValue(ctx, RequestIDKey, requestID), requestID
 }
 
@@ -83,44 +245,115 @@ func (sc *ServerContract) cleanup(requestID string) {
 	sc.callsLock.Lock()
 	defer sc.callsLock.Unlock()
 
-	if _, ok := sc.unaryRPCCalls[requestID]; ok {
+	if calls, ok := sc.unaryRPCCalls[requestID]; ok {
+		for _, methodCalls := range calls {
+			for _, call := range methodCalls {
+				delete(sc.callsByID, call.ID)
+			}
+		}
 		delete(sc.unaryRPCCalls, requestID)
-		delete(sc.callCnt, requestID)
 	}
+	delete(sc.callCnt, requestID)
 }
 
 // UnaryServerInterceptor returns a new unary server interceptor for
 // monitoring server contracts.
 func (sc *ServerContract) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
-	sc.contractsLock.Lock()
-	defer sc.contractsLock.Unlock()
-	sc.serve = true
-
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		t := sc.table()
+		replayed, isReplay := ctx.Value(replayKey).(*UnaryRPCCall)
+		if !sc.checking(info.FullMethod) || (!isReplay && !sc.sampled(ctx, t, info.FullMethod)) {
+			return handler(context.WithValue(ctx, uncheckedKey, true), req)
+		}
+
 		var requestID string
 		ctx, requestID = sc.generateRequestID(ctx)
+		defer sc.cleanup(requestID)
+		s := sc.incomingSpan(ctx, requestID)
+		ctx = context.WithValue(ctx, spanKey, s)
+		ctx = context.WithValue(ctx, fullMethodKey, info.FullMethod)
+		ctx = context.WithValue(ctx, tableKey, t)
+		if t.requiresDeadlinePropagation(info.FullMethod) {
+			deadline, ok := ctx.Deadline()
+			ctx = context.WithValue(ctx, deadlineKey, incomingDeadline{fullMethod: info.FullMethod, deadline: deadline, ok: ok})
+		}
 
-		c, ok := sc.unaryRPCContracts[info.FullMethod]
+		chk := &checker{sc: sc, fullMethod: info.FullMethod, req: req}
+		t.checkIncomingMetadata(ctx, chk)
+		if sc.validator != nil {
+			chk.observe(validationViolation, sc.validate(req))
+		}
+		c, ok := t.unaryRPCContracts[info.FullMethod]
+		state := t.rpcs[info.FullMethod]
 		if ok {
-			for _, preCondition := range c.PreConditions {
-				err := invokePreCondition(preCondition, req)
-				if err != nil {
-					sc.logFunc(err, info.FullMethod, req)
+			for _, cond := range c.PreConditions {
+				if name := conditionName(cond, preConditionViolation); chk.enabled(name) {
+					chk.observe(name, invokePreCondition(cond, req))
 				}
 			}
 		}
+		if v := chk.rejected(); v != nil {
+			return nil, status.Error(codes.InvalidArgument, v.Error())
+		}
 
+		reqSnapshot := sc.snapshot(req)
+		start := time.Now()
 		resp, handlerErr := handler(ctx, req)
+		end := time.Now()
+		if isReplay && !replayed.EndTime.IsZero() {
+			start, end = replayed.StartTime, replayed.EndTime
+		}
+		respSnapshot := sc.snapshot(resp)
+		chk.req = reqSnapshot
+		chk.respond(respSnapshot, handlerErr)
+
+		if handlerErr == nil && sc.validator != nil {
+			chk.observe(validationViolation, sc.validate(respSnapshot))
+		}
 
 		if ok {
-			for _, postCondition := range c.PostConditions {
-				err := invokePostCondition(postCondition, resp, handlerErr, req,
-					RPCCallHistory{requestID: requestID, sc: sc})
-				if err != nil {
-					sc.logFunc(err, info.FullMethod, req, resp, handlerErr)
+			history := RPCCallHistory{requestID: requestID, sc: sc}
+			for _, cond := range c.PostConditions {
+				if name := conditionName(cond, postConditionViolation); chk.enabled(name) {
+					chk.observe(name, invokePostCondition(cond, respSnapshot, handlerErr, reqSnapshot, history))
 				}
 			}
-			sc.cleanup(requestID)
+			if c.AllowedCodes != nil || len(c.StatusConditions) > 0 {
+				chk.observe(statusViolation, c.checkStatus(handlerErr)...)
+			}
+			errs, recoveries := state.checkLatency(c, end, end.Sub(start))
+			if c.MaxLatency > 0 || len(c.LatencyObjectives) > 0 {
+				chk.observe(latencyViolation, errs...)
+			}
+			for _, recovery := range recoveries {
+				sc.logFunc(recovery, info.FullMethod)
+			}
+			if cache := state.idempotency; cache != nil {
+				chk.observe(idempotencyViolation, cache.check(ctx, reqSnapshot, respSnapshot, handlerErr))
+			}
+			if p := state.callSequence; p != nil && handlerErr == nil {
+				chk.observe(callSequenceViolation, p.Match(history.All()))
+			}
+		}
+		if sc.collector != nil && !isReplay {
+			md, _ := metadata.FromIncomingContext(ctx)
+			deadline, _ := ctx.Deadline()
+			sc.collect(s, &UnaryRPCCall{
+				ID:              s.callID,
+				FullMethod:      info.FullMethod,
+				Request:         reqSnapshot,
+				Response:        respSnapshot,
+				Error:           handlerErr,
+				RequestMetadata: md,
+				StartTime:       start,
+				EndTime:         end,
+				Duration:        end.Sub(start),
+				Deadline:        deadline,
+				Attempts:        1,
+			}, requestID)
+		}
+		if v := chk.rejected(); v != nil {
+			return nil, status.Error(codes.Internal, v.Error())
 		}
 		return resp, handlerErr
 	}
@@ -130,27 +363,143 @@ func (sc *ServerContract) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 // RPC calls made by the client.
 func (sc *ServerContract) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-		err := invoker(ctx, method, req, reply, cc, opts...)
+		if ctx.Value(uncheckedKey) != nil {
+			// The call is made by a request that is not checked, e.g., because
+			// it is not sampled.
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		sc.checkDeadline(ctx, method, req)
 
 		requestID, ok := ctx.Value(RequestIDKey).(string)
-		if ok {
-			sc.callsLock.Lock()
-			defer sc.callsLock.Unlock()
-
-			if _, ok := sc.unaryRPCCalls[requestID]; !ok {
-				sc.unaryRPCCalls[requestID] = make(map[string][]*UnaryRPCCall)
-				sc.callCnt[requestID] = 0
+		if !ok {
+			if sc.requestContext {
+				sc.reportCall(requestContextViolation, fmt.Errorf("call to %s is not made with the context of a served request", method), "", method, req)
 			}
-			call := &UnaryRPCCall{
-				FullMethod: method,
-				Request:    req,
-				Response:   reply,
-				Error:      err,
-				Order:      sc.callCnt[requestID],
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		s, _ := ctx.Value(spanKey).(span)
+		parent := parentID(ctx)
+		call := sc.start(requestID, method, sc.snapshot(req))
+		for _, opt := range opts {
+			if c, ok := opt.(captureCall); ok {
+				*c.call = call
 			}
-			sc.unaryRPCCalls[requestID][method] = append(sc.unaryRPCCalls[requestID][method], call)
-			sc.callCnt[requestID]++
 		}
+		call.RequestMetadata, _ = metadata.FromOutgoingContext(ctx)
+		sc.checkOutgoingMetadata(ctx, method, call.RequestMetadata, req)
+		ctx = context.WithValue(ctx, parentKey, call)
+		if sc.collector != nil {
+			// The trace headers are only useful to services that report to the
+			// collector, so they are not sent to anyone otherwise.
+			ctx = metadata.AppendToOutgoingContext(ctx, traceIDHeader, s.traceID, callIDHeader, call.ID)
+		}
+		attempts := &callAttempts{sc: sc}
+		ctx = context.WithValue(ctx, attemptsKey, attempts)
+
+		var header, trailer metadata.MD
+		opts = append(opts, grpc.Header(&header), grpc.Trailer(&trailer))
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		end := time.Now()
+
+		call.Response = sc.snapshot(reply)
+		call.Error = err
+		call.Header = header
+		call.Trailer = trailer
+		call.EndTime = end
+		call.Deadline, _ = ctx.Deadline()
+		call.Attempts = int(atomic.LoadInt32(&attempts.n))
+		if recorded, ok := ctx.Value(replayCallKey).(*UnaryRPCCall); ok && !recorded.EndTime.IsZero() {
+			// Keep the recorded timing of a replayed call.
+			call.StartTime, call.EndTime = recorded.StartTime, recorded.EndTime
+			call.Attempts = recorded.Attempts
+		}
+		call.Duration = call.EndTime.Sub(call.StartTime)
+		if call.Attempts == 0 {
+			call.Attempts = 1
+		}
+		sc.finish(requestID, parent, call)
 		return err
 	}
 }
+
+// start starts recording a downstream call of the request. The call is not
+// part of the call history until it is passed to finish, so that the history
+// only has completed calls.
+func (sc *ServerContract) start(requestID, method string, req interface{}) *UnaryRPCCall {
+	sc.callsLock.Lock()
+	defer sc.callsLock.Unlock()
+
+	call := &UnaryRPCCall{
+		FullMethod: method,
+		Request:    req,
+		Order:      sc.callCnt[requestID],
+		StartTime:  time.Now(),
+	}
+	for {
+		call.ID = shortID()
+		_, recorded := sc.callsByID[call.ID]
+		_, started := sc.inflight[call.ID]
+		if !recorded && !started {
+			break
+		}
+	}
+	sc.inflight[call.ID] = call
+	if _, ok := sc.callCnt[requestID]; ok {
+		sc.callCnt[requestID]++
+	}
+	return call
+}
+
+// finish adds a completed call to the call history of the request. The call
+// is nested in the call with the given parent ID if that call is recorded by
+// sc, whether or not the parent has completed. Calls that complete after
+// their request was served are dropped.
+func (sc *ServerContract) finish(requestID, parentID string, call *UnaryRPCCall) {
+	sc.callsLock.Lock()
+	defer sc.callsLock.Unlock()
+
+	delete(sc.inflight, call.ID)
+	if _, ok := sc.callCnt[requestID]; !ok {
+		return
+	}
+	parent, ok := sc.inflight[parentID]
+	if !ok {
+		parent, ok = sc.callsByID[parentID]
+	}
+	if ok {
+		call.Parent = parent
+		parent.Children = append(parent.Children, call)
+		parent.Children.Ordered()
+	}
+	if _, ok := sc.unaryRPCCalls[requestID]; !ok {
+		sc.unaryRPCCalls[requestID] = make(map[string][]*UnaryRPCCall)
+	}
+	sc.callsByID[call.ID] = call
+	sc.unaryRPCCalls[requestID][call.FullMethod] = append(sc.unaryRPCCalls[requestID][call.FullMethod], call)
+}
+
+// snapshot returns a deep copy of a protocol buffer message if snapshots are enabled.
+func (sc *ServerContract) snapshot(v interface{}) interface{} {
+	if !sc.snapshots {
+		return v
+	}
+	m, ok := v.(proto.Message)
+	if !ok || !m.ProtoReflect().IsValid() {
+		return v
+	}
+	return proto.Clone(m)
+}
+
+// validate validates a protocol buffer message if a validator is set.
+func (sc *ServerContract) validate(v interface{}) error {
+	if sc.validator == nil {
+		return nil
+	}
+	m, ok := v.(proto.Message)
+	if !ok || !m.ProtoReflect().IsValid() {
+		return nil
+	}
+	return sc.validator.Validate(m)
+}
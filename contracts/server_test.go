@@ -0,0 +1,326 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSnapshots(t *testing.T) {
+	tests := []struct {
+		name      string
+		snapshots bool
+		want      string
+	}{
+		{name: "snapshots", snapshots: true, want: "sent"},
+		{name: "references", want: "changed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req, lookupReq, lookupResp string
+			var opts []ServerOption
+			if tt.snapshots {
+				opts = append(opts, WithSnapshots())
+			}
+			sc, _ := newServerContract(t, []*ServiceContract{{
+				ServiceName: frontService,
+				RPCContracts: []*UnaryRPCContract{{
+					MethodName: "Get",
+					PostConditions: []Condition{func(resp *wrapperspb.StringValue, respErr error, in *wrapperspb.StringValue, calls RPCCallHistory) error {
+						req = in.Value
+						if lookup, err := calls.All().First(); err == nil {
+							lookupReq = lookup.Request.(*wrapperspb.StringValue).Value
+							lookupResp = lookup.Response.(*wrapperspb.StringValue).Value
+						}
+						return nil
+					}},
+				}},
+			}}, opts...)
+			backCC := serveBack(t, sc, nil)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				lookupReq, lookupResp := str("sent"), new(wrapperspb.StringValue)
+				err := backCC.Invoke(ctx, lookupMethod, lookupReq, lookupResp)
+				lookupReq.Value, lookupResp.Value, in.Value = "changed", "changed", "changed"
+				return in, err
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "sent"); err != nil {
+				t.Fatal(err)
+			}
+			if req != tt.want || lookupReq != tt.want || lookupResp != tt.want {
+				t.Errorf("postcondition saw request %q and downstream call %q -> %q, want %q",
+					req, lookupReq, lookupResp, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterNilContract(t *testing.T) {
+	tests := []struct {
+		name        string
+		svcContract *ServiceContract
+	}{
+		{name: "nil service contract"},
+		{name: "nil rpc contract", svcContract: &ServiceContract{ServiceName: frontService, RPCContracts: []*UnaryRPCContract{nil}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewServerContract(t.Log).RegisterServiceContract(tt.svcContract); err == nil {
+				t.Error("RegisterServiceContract accepted a nil contract")
+			}
+		})
+	}
+}
+
+// failing returns a contract of test.Front/Get whose named precondition
+// always fails, so that the violations show which contract was checked.
+func failing(name string) *ServiceContract {
+	return &ServiceContract{
+		ServiceName: frontService,
+		RPCContracts: []*UnaryRPCContract{{
+			MethodName: "Get",
+			PreConditions: []Condition{Named(name, func(req *wrapperspb.StringValue) error {
+				return errors.New("failed")
+			})},
+		}},
+	}
+}
+
+func TestRegisterWhileServing(t *testing.T) {
+	sc, v := newServerContract(t, nil)
+	frontCC := serveFront(t, sc, testservice.Echo)
+	if _, err := call(context.Background(), frontCC, getMethod, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sc.RegisterServiceContract(failing("new")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(context.Background(), frontCC, getMethod, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.names(); !slices.Equal(got, []string{"new"}) {
+		t.Errorf("violations = %v, want [new]", got)
+	}
+	if err := sc.RegisterServiceContract(failing("duplicate")); err == nil {
+		t.Error("RegisterServiceContract accepted a duplicate contract")
+	}
+	for _, c := range []*ServiceContract{
+		nil,
+		{ServiceName: backService, RPCContracts: []*UnaryRPCContract{nil}},
+		{ServiceName: backService, RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup", LatencyObjectives: []*LatencyObjective{nil}}}},
+	} {
+		if err := sc.RegisterServiceContract(c); err == nil || !strings.Contains(err.Error(), "must not be nil") {
+			t.Errorf("RegisterServiceContract(%+v) = %v, want a nil contract error", c, err)
+		}
+	}
+}
+
+func TestUnregisterServiceContract(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		want    []string
+		wantErr bool
+	}{
+		{name: "registered", service: frontService},
+		{name: "not registered", service: backService, want: []string{"old"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The second contract of test.Front has no RPC contracts, and is
+			// removed with the first.
+			sc, v := newServerContract(t, []*ServiceContract{failing("old"), {ServiceName: frontService}})
+			frontCC := serveFront(t, sc, testservice.Echo)
+			call(context.Background(), frontCC, getMethod, "a")
+			v.mu.Lock()
+			v.vs = nil
+			v.mu.Unlock()
+
+			err := sc.UnregisterServiceContract(tt.service)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnregisterServiceContract() = %v, want error %v", err, tt.wantErr)
+			}
+			call(context.Background(), frontCC, getMethod, "a")
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+			removed := !tt.wantErr
+			if got := sc.RPCContract(getMethod) == nil; got != removed {
+				t.Errorf("RPCContract() is nil = %v, want %v", got, removed)
+			}
+			if got := len(sc.ServiceContracts()) == 0; got != removed {
+				t.Errorf("ServiceContracts() = %v", sc.ServiceContracts())
+			}
+			// The counters of the removed conditions are dropped.
+			if got := len(sc.Conditions()) == 0; got != removed {
+				t.Errorf("Conditions() = %+v", sc.Conditions())
+			}
+		})
+	}
+}
+
+func TestReplaceServiceContract(t *testing.T) {
+	invalid := &ServiceContract{
+		ServiceName:  backService,
+		RPCContracts: []*UnaryRPCContract{{MethodName: "Lookup", CallSequence: "("}},
+	}
+	tests := []struct {
+		name     string
+		replaced []*ServiceContract
+		want     []string
+		wantErr  bool
+	}{
+		{name: "replaced", replaced: []*ServiceContract{failing("new")}, want: []string{"new"}},
+		{name: "emptied", replaced: []*ServiceContract{{ServiceName: frontService}}},
+		{name: "other service added", replaced: []*ServiceContract{{ServiceName: backService}}, want: []string{"old"}},
+		{name: "none", want: []string{"old"}},
+		{name: "invalid", replaced: []*ServiceContract{failing("new"), invalid}, want: []string{"old"}, wantErr: true},
+		{name: "invalid contract", replaced: []*ServiceContract{failing("new"), {ServiceName: backService, RPCContracts: []*UnaryRPCContract{nil}}}, want: []string{"old"}, wantErr: true},
+		{name: "nil contract", replaced: []*ServiceContract{failing("new"), nil}, want: []string{"old"}, wantErr: true},
+		{name: "duplicate", replaced: []*ServiceContract{failing("new"), failing("newer")}, want: []string{"old"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{failing("old")})
+			frontCC := serveFront(t, sc, testservice.Echo)
+
+			err := sc.ReplaceServiceContract(tt.replaced...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReplaceServiceContract() = %v, want error %v", err, tt.wantErr)
+			}
+			call(context.Background(), frontCC, getMethod, "a")
+			if got := v.names(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceWhileServing(t *testing.T) {
+	sc, v := newServerContract(t, []*ServiceContract{failing("v0")})
+	frontCC := serveFront(t, sc, testservice.Echo)
+
+	const calls = 50
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call(context.Background(), frontCC, getMethod, "a")
+		}()
+	}
+	for i := 1; i <= 10; i++ {
+		if err := sc.ReplaceServiceContract(failing(fmt.Sprintf("v%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wg.Wait()
+
+	// Every RPC is checked against exactly one version of the contract.
+	if got := len(v.names()); got != calls {
+		t.Errorf("%d violations for %d RPCs", got, calls)
+	}
+}
+
+func TestReplaceDuringRequest(t *testing.T) {
+	requiring := func(key string) *ServiceContract {
+		return &ServiceContract{
+			ServiceName: frontService,
+			DownstreamMetadata: []*DownstreamMetadataContract{{
+				Target:           backService,
+				MetadataContract: MetadataContract{Required: []string{key}},
+			}},
+		}
+	}
+	sc, v := newServerContract(t, []*ServiceContract{requiring("x-old")})
+	backCC := serveBack(t, sc, nil)
+	frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+		if err := sc.ReplaceServiceContract(requiring("x-new")); err != nil {
+			return nil, err
+		}
+		out, err := call(metadata.AppendToOutgoingContext(ctx, "x-old", "1"), backCC, lookupMethod, in.Value)
+		return str(out), err
+	})
+
+	// The downstream call is checked against the contract the request
+	// started with, not the one that replaced it.
+	if _, err := call(context.Background(), frontCC, getMethod, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.names(); len(got) != 0 {
+		t.Errorf("violations = %v, want none", got)
+	}
+	if _, err := call(context.Background(), frontCC, getMethod, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := v.names(); !slices.Equal(got, []string{metadataViolation}) {
+		t.Errorf("violations = %v, want [%s]", got, metadataViolation)
+	}
+}
+
+func TestReplaceResetsCounts(t *testing.T) {
+	sc, _ := newServerContract(t, []*ServiceContract{failing("old")})
+	frontCC := serveFront(t, sc, testservice.Echo)
+	failed := func() uint64 {
+		var n uint64
+		for _, c := range sc.Conditions() {
+			n += c.Failed
+		}
+		return n
+	}
+
+	call(context.Background(), frontCC, getMethod, "a")
+	if got := failed(); got != 1 {
+		t.Fatalf("%d failed checks before replacing, want 1", got)
+	}
+	if err := sc.ReplaceServiceContract(failing("old")); err != nil {
+		t.Fatal(err)
+	}
+	if got := failed(); got != 0 {
+		t.Errorf("%d failed checks after replacing, want 0", got)
+	}
+	call(context.Background(), frontCC, getMethod, "a")
+	if got := failed(); got != 1 {
+		t.Errorf("%d failed checks after a call, want 1", got)
+	}
+}
+
+func TestRegisterDoesNotModifyContracts(t *testing.T) {
+	contract := func() *ServiceContract {
+		return &ServiceContract{
+			ServiceName:      frontService,
+			IncomingMetadata: &MetadataContract{Patterns: map[string]string{"x-request-id": "[0-9]+"}},
+			RPCContracts: []*UnaryRPCContract{{
+				MethodName:        "Get",
+				CallSequence:      "Lookup*",
+				LatencyObjectives: []*LatencyObjective{{Percentile: 99, Threshold: time.Second}},
+				Idempotency:       &IdempotencyContract{KeyMetadata: "idempotency-key"},
+			}},
+		}
+	}
+	sc, _ := newServerContract(t, nil)
+	registered := contract()
+	if err := sc.RegisterServiceContract(registered); err != nil {
+		t.Fatal(err)
+	}
+	frontCC := serveFront(t, sc, testservice.Echo)
+	call(metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "1", "idempotency-key", "k"), frontCC, getMethod, "a")
+	if err := sc.ReplaceServiceContract(contract()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(registered, contract()) {
+		t.Errorf("registered contract was modified: %+v", registered)
+	}
+	if sc.RPCContract(getMethod) == registered.RPCContracts[0] {
+		t.Error("RPCContract() returned the replaced contract")
+	}
+}
@@ -0,0 +1,88 @@
+package contracts
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// traceIDHeader is the metadata key used to propagate the trace ID to
+	// downstream services. The trace headers are only sent if the
+	// ServerContract reports to a collector.
+	traceIDHeader = "contracts-trace-id"
+	// callIDHeader is the metadata key used to propagate the ID of a downstream call.
+	callIDHeader = "contracts-call-id"
+)
+
+// span identifies a served RPC within a call tree.
+type span struct {
+	traceID string
+	callID  string
+	root    bool
+}
+
+// incomingSpan extracts the span of a served RPC from the incoming metadata.
+// A new root span is created if the caller did not propagate one or is not
+// trusted to.
+func (sc *ServerContract) incomingSpan(ctx context.Context, requestID string) span {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if sc.trustedCaller != nil && !sc.trustedCaller(ctx) {
+		md = nil
+	}
+	traceIDs, callIDs := md.Get(traceIDHeader), md.Get(callIDHeader)
+	if len(traceIDs) == 0 || len(callIDs) == 0 {
+		return span{traceID: requestID, callID: shortID(), root: true}
+	}
+	return span{traceID: traceIDs[0], callID: callIDs[0]}
+}
+
+// parentID returns the ID of the call that RPC calls made with ctx are nested in.
+func parentID(ctx context.Context) string {
+	switch parent := ctx.Value(parentKey).(type) {
+	case string:
+		return parent
+	case *UnaryRPCCall:
+		return parent.ID
+	}
+	if s, ok := ctx.Value(spanKey).(span); ok {
+		return s.callID
+	}
+	return ""
+}
+
+// WithParent returns a copy of ctx in which RPC calls are recorded as children
+// of the call with the given ID. It can be used to group the downstream calls
+// made by a sub-operation or goroutine under the call that started it, even
+// if that call has already completed. Use CaptureCall to get the recorded call
+// of a handler's call:
+//
+//	var lookup *contracts.UnaryRPCCall
+//	resp, err := client.Lookup(ctx, req, contracts.CaptureCall(&lookup))
+//	...
+//	go process(contracts.WithParent(ctx, lookup.ID), resp)
+func WithParent(ctx context.Context, parentID string) context.Context {
+	return context.WithValue(ctx, parentKey, parentID)
+}
+
+// CallFromContext returns the downstream call that is being made with ctx,
+// i.e., ctx is the context that UnaryClientInterceptor passes to the
+// interceptors and the invoker that run inside it.
+func CallFromContext(ctx context.Context) (*UnaryRPCCall, bool) {
+	call, ok := ctx.Value(parentKey).(*UnaryRPCCall)
+	return call, ok
+}
+
+// CaptureCall returns a call option that makes UnaryClientInterceptor store
+// the recorded call in *call when the call starts. *call is left unchanged if
+// the call is not recorded, i.e., it is not made with the context of a served
+// request.
+func CaptureCall(call **UnaryRPCCall) grpc.CallOption {
+	return captureCall{call: call}
+}
+
+type captureCall struct {
+	grpc.EmptyCallOption
+	call **UnaryRPCCall
+}
@@ -0,0 +1,125 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// newEnv returns the CEL environment of the conditions of a method, which
+// knows the message types of the method. Postconditions may also refer to
+// the response, the status code and the calls.
+func newEnv(md protoreflect.MethodDescriptor, post bool) (*cel.Env, error) {
+	opts := []cel.EnvOption{
+		cel.TypeDescs(typeFiles(md)...),
+		cel.Variable("request", cel.ObjectType(string(md.Input().FullName()))),
+	}
+	if post {
+		opts = append(opts,
+			cel.Variable("response", cel.ObjectType(string(md.Output().FullName()))),
+			cel.Variable("code", cel.StringType),
+			cel.Variable("calls", cel.MapType(cel.StringType, cel.IntType)),
+		)
+	}
+	return cel.NewEnv(opts...)
+}
+
+// typeFiles returns the files that declare the message types of a method and
+// the files they import, transitively.
+func typeFiles(md protoreflect.MethodDescriptor) []any {
+	var res []any
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if fd.IsPlaceholder() || seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		res = append(res, fd)
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+	}
+	add(md.Input().ParentFile())
+	add(md.Output().ParentFile())
+	return res
+}
+
+// compileCondition compiles a condition in env into a program that
+// evaluates it.
+func compileCondition(env *cel.Env, src string) (cel.Program, error) {
+	ast, iss := env.Compile(src)
+	if iss.Err() != nil {
+		// Report the issues on one line, without the source excerpts.
+		var msgs []string
+		for _, e := range iss.Errors() {
+			msgs = append(msgs, fmt.Sprintf("%d:%d: %s", e.Location.Line(), e.Location.Column()+1, e.Message))
+		}
+		return nil, errors.New(strings.Join(msgs, "; "))
+	}
+	if !ast.OutputType().IsExactType(cel.BoolType) {
+		return nil, fmt.Errorf("expression has type %s, expected bool", ast.OutputType())
+	}
+	return env.Program(ast, cel.EvalOptions(cel.OptOptimize))
+}
+
+// evalCondition evaluates a condition and returns an error if it does not
+// hold.
+func evalCondition(prg cel.Program, vars map[string]any) error {
+	out, _, err := prg.Eval(vars)
+	if err != nil {
+		return err
+	}
+	if out != types.True {
+		return errors.New("condition is false")
+	}
+	return nil
+}
+
+// message returns m, or an empty message of the given type if m is nil or
+// a nil pointer, so that the fields of a missing response have their default
+// values.
+func message(m proto.Message, md protoreflect.MessageDescriptor) proto.Message {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return dynamicpb.NewMessage(md)
+	}
+	return m
+}
+
+// callCounts is the value of calls in postconditions. It maps methods, i.e.,
+// package.service/method, and method names alone to the number of successful
+// downstream calls to them, which is zero for methods that were not called.
+type callCounts struct {
+	traits.Mapper
+}
+
+func newCallCounts(calls contracts.RPCCallHistory) callCounts {
+	counts := make(map[string]int64)
+	for _, call := range calls.All().Successful() {
+		method := strings.TrimPrefix(call.FullMethod, "/")
+		counts[method]++
+		counts[method[strings.LastIndex(method, "/")+1:]]++
+	}
+	return callCounts{types.DefaultTypeAdapter.NativeToValue(counts).(traits.Mapper)}
+}
+
+func (c callCounts) Find(key ref.Val) (ref.Val, bool) {
+	if v, ok := c.Mapper.Find(key); ok || types.IsError(v) {
+		return v, ok
+	}
+	return types.IntZero, true
+}
+
+func (c callCounts) Get(key ref.Val) ref.Val {
+	v, _ := c.Find(key)
+	return v
+}
@@ -0,0 +1,425 @@
+// Package spec implements contract files, which describe service contracts
+// declaratively in JSON, so that they can be shared between services and
+// inspected by tools without compiling Go code:
+//
+//	{
+//	  "services": [{
+//	    "service": "mynote.NoteService",
+//	    "methods": [{
+//	      "method": "GetNote",
+//	      "preconditions": ["request.note_id >= 0"],
+//	      "postconditions": [
+//	        "code != \"OK\" || calls[\"mynote.AuthService/Authenticate\"] > 0",
+//	        "code != \"OK\" || response.note_id == request.note_id"
+//	      ],
+//	      "allowed_codes": ["NOT_FOUND", "UNAUTHENTICATED"],
+//	      "max_latency": "100ms"
+//	    }]
+//	  }]
+//	}
+//
+// Conditions are boolean CEL expressions (https://cel.dev) over the request
+// and, in postconditions, the response, which are type-checked against the
+// descriptors of the messages when the file is compiled. Fields are accessed
+// by their proto names, e.g., request.note.note_id, and enum values by their
+// full names, e.g., mynote.Kind.KIND_LIST. The fields of a missing response
+// have their default values. Postconditions may also refer to:
+//
+//   - code, the name of the returned status code, e.g., "OK" or "NOT_FOUND";
+//   - calls, a map from methods to the number of successful downstream calls
+//     to them, e.g., calls["mynote.AuthService/Authenticate"]. A method name
+//     without a service, e.g., calls["Authenticate"], counts the calls to the
+//     method in any service.
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// File is a contract file.
+type File struct {
+	Services []*Service `json:"services"`
+}
+
+// Service is the contract of a service. See contracts.ServiceContract.
+type Service struct {
+	// Name is the name of the service, i.e., package.service.
+	Name                       string                `json:"service"`
+	RequireDeadlinePropagation bool                  `json:"require_deadline_propagation,omitempty"`
+	IncomingMetadata           *Metadata             `json:"incoming_metadata,omitempty"`
+	DownstreamMetadata         []*DownstreamMetadata `json:"downstream_metadata,omitempty"`
+	Methods                    []*Method             `json:"methods"`
+}
+
+// Method is the contract of a unary method. See contracts.UnaryRPCContract.
+type Method struct {
+	// Name is the method name only, without the service name or package name.
+	Name           string   `json:"method"`
+	PreConditions  []string `json:"preconditions,omitempty"`
+	PostConditions []string `json:"postconditions,omitempty"`
+	CallSequence   string   `json:"call_sequence,omitempty"`
+	// AllowedCodes are the names of the allowed status codes, e.g., NOT_FOUND.
+	AllowedCodes []string `json:"allowed_codes,omitempty"`
+	// MaxLatency is a duration, e.g., 100ms.
+	MaxLatency         string                `json:"max_latency,omitempty"`
+	LatencyObjectives  []*LatencyObjective   `json:"latency_objectives,omitempty"`
+	Idempotency        *Idempotency          `json:"idempotency,omitempty"`
+	IncomingMetadata   *Metadata             `json:"incoming_metadata,omitempty"`
+	DownstreamMetadata []*DownstreamMetadata `json:"downstream_metadata,omitempty"`
+}
+
+// Metadata is a metadata contract. See contracts.MetadataContract.
+type Metadata struct {
+	Required  []string          `json:"required,omitempty"`
+	Forbidden []string          `json:"forbidden,omitempty"`
+	Patterns  map[string]string `json:"patterns,omitempty"`
+}
+
+// DownstreamMetadata is a contract on the outgoing metadata of downstream
+// calls. See contracts.DownstreamMetadataContract.
+type DownstreamMetadata struct {
+	Target string `json:"target"`
+	Metadata
+}
+
+// LatencyObjective is a latency objective. See contracts.LatencyObjective.
+type LatencyObjective struct {
+	Percentile float64 `json:"percentile"`
+	// Threshold and Window are durations, e.g., 100ms.
+	Threshold  string `json:"threshold"`
+	Window     string `json:"window,omitempty"`
+	MinSamples int    `json:"min_samples,omitempty"`
+}
+
+// Idempotency marks a method as idempotent. See contracts.IdempotencyContract.
+type Idempotency struct {
+	KeyMetadata string `json:"key_metadata,omitempty"`
+	KeyField    string `json:"key_field,omitempty"`
+	CacheSize   int    `json:"cache_size,omitempty"`
+}
+
+// Parse parses a contract file. Unknown fields are rejected.
+func Parse(data []byte) (*File, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	f := new(File)
+	if err := dec.Decode(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ReadFile reads and parses a contract file.
+func ReadFile(name string) (*File, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("spec: parsing %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// Compile returns the service contracts described by the file. The
+// descriptors of the services are looked up in files, or in
+// protoregistry.GlobalFiles if files is nil. Conditions are named by their
+// expressions, see contracts.Named.
+func (f *File) Compile(files *protoregistry.Files) ([]*contracts.ServiceContract, error) {
+	svcs, errs := f.compile(files)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return svcs, nil
+}
+
+// Lint returns all of the problems of the file, e.g., methods missing from
+// the descriptors and type errors in conditions. The descriptors of the
+// services are looked up in files, or in protoregistry.GlobalFiles if files is nil.
+func (f *File) Lint(files *protoregistry.Files) []error {
+	_, errs := f.compile(files)
+	return errs
+}
+
+func (f *File) compile(files *protoregistry.Files) ([]*contracts.ServiceContract, []error) {
+	if files == nil {
+		files = protoregistry.GlobalFiles
+	}
+	var svcs []*contracts.ServiceContract
+	var errs []error
+	seen := make(map[string]bool)
+	for i, s := range f.Services {
+		if s == nil {
+			errs = append(errs, fmt.Errorf("services[%d] is null", i))
+			continue
+		}
+		if seen[s.Name] {
+			errs = append(errs, fmt.Errorf("service %s: duplicate contract", s.Name))
+			continue
+		}
+		seen[s.Name] = true
+		svc, svcErrs := s.compile(files)
+		errs = append(errs, svcErrs...)
+		if len(svcErrs) == 0 {
+			svcs = append(svcs, svc)
+		}
+	}
+	return svcs, errs
+}
+
+func (s *Service) compile(files *protoregistry.Files) (*contracts.ServiceContract, []error) {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("service %s: "+format, append([]interface{}{s.Name}, args...)...))
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(s.Name))
+	if err != nil {
+		fail("not found")
+		return nil, errs
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		fail("not a service")
+		return nil, errs
+	}
+	if err := nullEntry("downstream_metadata", s.DownstreamMetadata); err != nil {
+		fail("%v", err)
+		return nil, errs
+	}
+
+	svc := &contracts.ServiceContract{
+		ServiceName:                s.Name,
+		RequireDeadlinePropagation: s.RequireDeadlinePropagation,
+		IncomingMetadata:           s.IncomingMetadata.compile(),
+		DownstreamMetadata:         compileDownstream(s.DownstreamMetadata),
+	}
+	seen := make(map[string]bool)
+	for i, m := range s.Methods {
+		if m == nil {
+			fail("methods[%d] is null", i)
+			continue
+		}
+		if seen[m.Name] {
+			fail("method %s: duplicate contract", m.Name)
+			continue
+		}
+		seen[m.Name] = true
+		md := sd.Methods().ByName(protoreflect.Name(m.Name))
+		if md == nil {
+			fail("method %s not found", m.Name)
+			continue
+		}
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			fail("method %s: streaming methods are not supported", m.Name)
+			continue
+		}
+		rpc, err := m.compile(md)
+		if err != nil {
+			for _, e := range unjoin(err) {
+				fail("method %s: %v", m.Name, e)
+			}
+			continue
+		}
+		svc.RPCContracts = append(svc.RPCContracts, rpc)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Let the library validate the rest of the contract, e.g., call sequences.
+	sc := contracts.NewServerContract(func(...interface{}) {})
+	if err := sc.RegisterServiceContract(svc); err != nil {
+		fail("%v", err)
+		return nil, errs
+	}
+	return svc, nil
+}
+
+// nullEntry returns an error positioned at the first null entry of a list of
+// the file, if any.
+func nullEntry[T any](name string, list []*T) error {
+	for i, e := range list {
+		if e == nil {
+			return fmt.Errorf("%s[%d] is null", name, i)
+		}
+	}
+	return nil
+}
+
+func unjoin(err error) []error {
+	if j, ok := err.(interface{ Unwrap() []error }); ok {
+		return j.Unwrap()
+	}
+	return []error{err}
+}
+
+func (m *Method) compile(md protoreflect.MethodDescriptor) (*contracts.UnaryRPCContract, error) {
+	if err := errors.Join(nullEntry("downstream_metadata", m.DownstreamMetadata),
+		nullEntry("latency_objectives", m.LatencyObjectives)); err != nil {
+		return nil, err
+	}
+	var errs []error
+	rpc := &contracts.UnaryRPCContract{
+		MethodName:         m.Name,
+		CallSequence:       m.CallSequence,
+		IncomingMetadata:   m.IncomingMetadata.compile(),
+		DownstreamMetadata: compileDownstream(m.DownstreamMetadata),
+	}
+
+	pre, err := newEnv(md, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range m.PreConditions {
+		prg, err := compileCondition(pre, src)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("precondition %q: %v", src, err))
+			continue
+		}
+		rpc.PreConditions = append(rpc.PreConditions, contracts.Named(src, func(req proto.Message) error {
+			if err := checkType(req, md.Input()); err != nil {
+				return err
+			}
+			return evalCondition(prg, map[string]any{"request": message(req, md.Input())})
+		}))
+	}
+	post, err := newEnv(md, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, src := range m.PostConditions {
+		prg, err := compileCondition(post, src)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("postcondition %q: %v", src, err))
+			continue
+		}
+		rpc.PostConditions = append(rpc.PostConditions, contracts.Named(src,
+			func(resp proto.Message, respErr error, req proto.Message, calls contracts.RPCCallHistory) error {
+				if err := checkType(req, md.Input()); err != nil {
+					return err
+				}
+				if err := checkType(resp, md.Output()); err != nil {
+					return err
+				}
+				return evalCondition(prg, map[string]any{
+					"request":  message(req, md.Input()),
+					"response": message(resp, md.Output()),
+					"code":     code.Code(status.Code(respErr)).String(),
+					"calls":    newCallCounts(calls),
+				})
+			}))
+	}
+
+	if m.CallSequence != "" {
+		if _, err := contracts.CompileSequence(m.CallSequence); err != nil {
+			errs = append(errs, fmt.Errorf("call sequence: %v", err))
+		}
+	}
+	for _, name := range m.AllowedCodes {
+		c, ok := code.Code_value[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown status code %q", name))
+			continue
+		}
+		rpc.AllowedCodes = append(rpc.AllowedCodes, codes.Code(c))
+	}
+	if m.MaxLatency != "" {
+		d, err := time.ParseDuration(m.MaxLatency)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("max latency: %v", err))
+		}
+		rpc.MaxLatency = d
+	}
+	for _, o := range m.LatencyObjectives {
+		lo := &contracts.LatencyObjective{Percentile: o.Percentile, MinSamples: o.MinSamples}
+		var err error
+		if lo.Threshold, err = time.ParseDuration(o.Threshold); err != nil {
+			errs = append(errs, fmt.Errorf("latency objective threshold: %v", err))
+		}
+		if o.Window != "" {
+			if lo.Window, err = time.ParseDuration(o.Window); err != nil {
+				errs = append(errs, fmt.Errorf("latency objective window: %v", err))
+			}
+		}
+		rpc.LatencyObjectives = append(rpc.LatencyObjectives, lo)
+	}
+	if m.Idempotency != nil {
+		if m.Idempotency.KeyField != "" && md.Input().Fields().ByName(protoreflect.Name(m.Idempotency.KeyField)) == nil {
+			errs = append(errs, fmt.Errorf("idempotency key field: %s has no field %s", md.Input().FullName(), m.Idempotency.KeyField))
+		}
+		rpc.Idempotency = &contracts.IdempotencyContract{
+			KeyMetadata: m.Idempotency.KeyMetadata,
+			KeyField:    m.Idempotency.KeyField,
+			CacheSize:   m.Idempotency.CacheSize,
+		}
+	}
+	for _, mc := range append([]*Metadata{m.IncomingMetadata}, downstreamMetadata(m.DownstreamMetadata)...) {
+		if err := mc.check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return rpc, errors.Join(errs...)
+}
+
+// checkType returns an error if the message is not of the given type. Nil
+// messages are of any type.
+func checkType(m proto.Message, want protoreflect.MessageDescriptor) error {
+	if m == nil {
+		return nil
+	}
+	if got := m.ProtoReflect().Descriptor().FullName(); got != want.FullName() {
+		return fmt.Errorf("message has type %s, expected %s", got, want.FullName())
+	}
+	return nil
+}
+
+func (mc *Metadata) compile() *contracts.MetadataContract {
+	if mc == nil {
+		return nil
+	}
+	return &contracts.MetadataContract{Required: mc.Required, Forbidden: mc.Forbidden, Patterns: mc.Patterns}
+}
+
+func (mc *Metadata) check() error {
+	if mc == nil {
+		return nil
+	}
+	for key, pattern := range mc.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("metadata pattern of %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+func downstreamMetadata(dcs []*DownstreamMetadata) []*Metadata {
+	var res []*Metadata
+	for _, dc := range dcs {
+		res = append(res, &dc.Metadata)
+	}
+	return res
+}
+
+func compileDownstream(dcs []*DownstreamMetadata) []*contracts.DownstreamMetadataContract {
+	var res []*contracts.DownstreamMetadataContract
+	for _, dc := range dcs {
+		res = append(res, &contracts.DownstreamMetadataContract{Target: dc.Target, MetadataContract: *dc.Metadata.compile()})
+	}
+	return res
+}
@@ -0,0 +1,378 @@
+package spec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/contracttest"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const notesFile = `{
+  "services": [{
+    "service": "contracts.test.Notes",
+    "require_deadline_propagation": true,
+    "incoming_metadata": {"required": ["x-request-id"]},
+    "downstream_metadata": [{"target": "contracts.test.Auth", "forbidden": ["cookie"]}],
+    "methods": [{
+      "method": "GetNote",
+      "preconditions": ["request.note_id >= 0"],
+      "postconditions": [
+        "code != \"OK\" || calls[\"contracts.test.Auth/Authenticate\"] > 0",
+        "code != \"OK\" || response.note_id == request.note_id"
+      ],
+      "call_sequence": "Authenticate?",
+      "allowed_codes": ["NOT_FOUND", "UNAUTHENTICATED"],
+      "max_latency": "100ms",
+      "latency_objectives": [{"percentile": 99, "threshold": "50ms", "window": "1m", "min_samples": 10}],
+      "idempotency": {"key_field": "note_id", "cache_size": 8},
+      "incoming_metadata": {"patterns": {"x-request-id": "[0-9a-f]+"}}
+    }]
+  }]
+}`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "valid", data: notesFile},
+		{name: "empty", data: `{}`},
+		{name: "unknown field", data: `{"services": [{"service": "contracts.test.Notes", "method": []}]}`, wantErr: `unknown field "method"`},
+		{name: "wrong type", data: `{"services": {}}`, wantErr: "cannot unmarshal object"},
+		{name: "invalid json", data: `{"services": [`, wantErr: "unexpected EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Parse() = %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "contracts.json")
+	if err := os.WriteFile(name, []byte(`{"service": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(name); err == nil || !strings.HasPrefix(err.Error(), "spec: parsing "+name+":") {
+		t.Errorf("ReadFile() = %v, want a parse error naming the file", err)
+	}
+	if _, err := ReadFile(filepath.Join(dir, "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("ReadFile() = %v, want a not exist error", err)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	f, err := Parse([]byte(notesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svcs, err := f.Compile(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(svcs) != 1 || len(svcs[0].RPCContracts) != 1 {
+		t.Fatalf("Compile() = %v, want one service with one method", svcs)
+	}
+	svc, rpc := svcs[0], svcs[0].RPCContracts[0]
+	if svc.ServiceName != "contracts.test.Notes" || !svc.RequireDeadlinePropagation ||
+		!slices.Equal(svc.IncomingMetadata.Required, []string{"x-request-id"}) ||
+		len(svc.DownstreamMetadata) != 1 || svc.DownstreamMetadata[0].Target != "contracts.test.Auth" {
+		t.Errorf("service contract %+v does not match the file", svc)
+	}
+	if rpc.MethodName != "GetNote" || rpc.CallSequence != "Authenticate?" ||
+		!slices.Equal(rpc.AllowedCodes, []codes.Code{codes.NotFound, codes.Unauthenticated}) ||
+		rpc.MaxLatency != 100*time.Millisecond ||
+		len(rpc.PreConditions) != 1 || len(rpc.PostConditions) != 2 {
+		t.Errorf("method contract %+v does not match the file", rpc)
+	}
+	if lo := rpc.LatencyObjectives[0]; lo.Percentile != 99 || lo.Threshold != 50*time.Millisecond || lo.Window != time.Minute || lo.MinSamples != 10 {
+		t.Errorf("latency objective %+v does not match the file", lo)
+	}
+	if ic := rpc.Idempotency; ic.KeyField != "note_id" || ic.CacheSize != 8 {
+		t.Errorf("idempotency contract %+v does not match the file", ic)
+	}
+	if nc, ok := rpc.PreConditions[0].(*contracts.NamedCondition); !ok || nc.Name != "request.note_id >= 0" {
+		t.Errorf("precondition %v is not named by its expression", rpc.PreConditions[0])
+	}
+
+	// The services are looked up in the given files.
+	if _, err := f.Compile(new(protoregistry.Files)); err == nil || err.Error() != "service contracts.test.Notes: not found" {
+		t.Errorf("Compile() with no files = %v", err)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{name: "valid", data: notesFile},
+		{
+			name: "services",
+			data: `{"services": [
+				null,
+				{"service": "contracts.test.Missing", "methods": []},
+				{"service": "contracts.test.Note", "methods": []},
+				{"service": "contracts.test.Auth", "methods": []},
+				{"service": "contracts.test.Auth", "methods": []}
+			]}`,
+			want: []string{
+				"services[0] is null",
+				"service contracts.test.Missing: not found",
+				"service contracts.test.Note: not a service",
+				"service contracts.test.Auth: duplicate contract",
+			},
+		},
+		{
+			name: "methods",
+			data: `{"services": [{"service": "contracts.test.Notes", "methods": [
+				null,
+				{"method": "DeleteNote"},
+				{"method": "GetNote"},
+				{"method": "GetNote"}
+			]}]}`,
+			want: []string{
+				"service contracts.test.Notes: methods[0] is null",
+				"service contracts.test.Notes: method DeleteNote not found",
+				"service contracts.test.Notes: method GetNote: duplicate contract",
+			},
+		},
+		{
+			name: "null service entries",
+			data: `{"services": [{"service": "contracts.test.Notes", "downstream_metadata": [null], "methods": []}]}`,
+			want: []string{"service contracts.test.Notes: downstream_metadata[0] is null"},
+		},
+		{
+			name: "null method entries",
+			data: `{"services": [{"service": "contracts.test.Notes", "methods": [
+				{"method": "GetNote", "downstream_metadata": [null], "latency_objectives": [null]}
+			]}]}`,
+			want: []string{
+				"service contracts.test.Notes: method GetNote: downstream_metadata[0] is null",
+				"service contracts.test.Notes: method GetNote: latency_objectives[0] is null",
+			},
+		},
+		{
+			name: "method errors",
+			data: `{"services": [{"service": "contracts.test.Notes", "methods": [{
+				"method": "GetNote",
+				"preconditions": ["request.note_id", "response.note_id == 1"],
+				"postconditions": ["response.title == request.note_id"],
+				"call_sequence": "Authenticate(",
+				"allowed_codes": ["NOT_FOUND", "MISSING"],
+				"max_latency": "1 second",
+				"latency_objectives": [{"percentile": 99, "threshold": "fast", "window": "long"}],
+				"idempotency": {"key_field": "id"},
+				"incoming_metadata": {"patterns": {"x-request-id": "("}}
+			}]}]}`,
+			want: []string{
+				`service contracts.test.Notes: method GetNote: precondition "request.note_id": expression has type int, expected bool`,
+				`service contracts.test.Notes: method GetNote: precondition "response.note_id == 1": 1:1: undeclared reference to 'response'`,
+				`service contracts.test.Notes: method GetNote: postcondition "response.title == request.note_id": 1:16: found no matching overload for '_==_' applied to '(string, int)'`,
+				"service contracts.test.Notes: method GetNote: call sequence: ",
+				`service contracts.test.Notes: method GetNote: unknown status code "MISSING"`,
+				`service contracts.test.Notes: method GetNote: max latency: time: unknown unit " second" in duration "1 second"`,
+				`service contracts.test.Notes: method GetNote: latency objective threshold: time: invalid duration "fast"`,
+				`service contracts.test.Notes: method GetNote: latency objective window: time: invalid duration "long"`,
+				"service contracts.test.Notes: method GetNote: idempotency key field: contracts.test.GetNoteRequest has no field id",
+				"service contracts.test.Notes: method GetNote: metadata pattern of x-request-id: ",
+			},
+		},
+		{
+			name: "library errors",
+			data: `{"services": [{"service": "contracts.test.Notes", "methods": [
+				{"method": "GetNote", "latency_objectives": [{"percentile": 120, "threshold": "10ms"}]}
+			]}]}`,
+			want: []string{"service contracts.test.Notes: LatencyObjective percentile must be in (0, 100]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, err := range f.Lint(nil) {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Lint() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("Lint()[%d] = %s, want prefix %s", i, got[i], tt.want[i])
+				}
+			}
+			if _, err := f.Compile(nil); (err != nil) != (len(tt.want) > 0) {
+				t.Errorf("Compile() = %v, want an error iff Lint reports problems", err)
+			}
+		})
+	}
+}
+
+type notes struct {
+	testpb.UnimplementedNotesServer
+	auth testpb.AuthClient
+}
+
+// GetNote authenticates the caller if the request has a token, and returns
+// note 1 for a negative note ID.
+func (s *notes) GetNote(ctx context.Context, in *testpb.GetNoteRequest) (*testpb.Note, error) {
+	if in.Token != "" {
+		if _, err := s.auth.Authenticate(ctx, &testpb.AuthenticateRequest{Token: in.Token}); err != nil {
+			return nil, err
+		}
+	}
+	if in.NoteId == 404 {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	if in.NoteId < 0 {
+		return &testpb.Note{NoteId: 1}, nil
+	}
+	return &testpb.Note{NoteId: in.NoteId}, nil
+}
+
+type auth struct {
+	testpb.UnimplementedAuthServer
+}
+
+func (auth) Authenticate(ctx context.Context, in *testpb.AuthenticateRequest) (*testpb.AuthenticateResponse, error) {
+	return &testpb.AuthenticateResponse{UserId: 1}, nil
+}
+
+func TestConditions(t *testing.T) {
+	f, err := Parse([]byte(`{"services": [{"service": "contracts.test.Notes", "methods": [{
+		"method": "GetNote",
+		"preconditions": ["request.note_id >= 0"],
+		"postconditions": [
+			"code != \"OK\" || calls[\"contracts.test.Auth/Authenticate\"] > 0",
+			"code != \"OK\" || response.note_id == request.note_id"
+		]
+	}]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svcs, err := f.Compile(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := contracts.NewServerContract(t.Log)
+	if err := sc.RegisterServiceContract(svcs[0]); err != nil {
+		t.Fatal(err)
+	}
+	r := contracttest.Record(t, sc)
+	s := &notes{}
+	conn := contracttest.Serve(t, sc, func(gs *grpc.Server) {
+		testpb.RegisterNotesServer(gs, s)
+		testpb.RegisterAuthServer(gs, auth{})
+	})
+	s.auth = testpb.NewAuthClient(conn)
+	client := testpb.NewNotesClient(conn)
+
+	tests := []struct {
+		name string
+		req  *testpb.GetNoteRequest
+		want []string
+	}{
+		{name: "holds", req: &testpb.GetNoteRequest{NoteId: 1, Token: "t"}},
+		{name: "not found", req: &testpb.GetNoteRequest{NoteId: 404}},
+		{
+			name: "not authenticated",
+			req:  &testpb.GetNoteRequest{NoteId: 1},
+			want: []string{`code != "OK" || calls["contracts.test.Auth/Authenticate"] > 0`},
+		},
+		{
+			name: "negative note ID",
+			req:  &testpb.GetNoteRequest{NoteId: -1, Token: "t"},
+			want: []string{"request.note_id >= 0", `code != "OK" || response.note_id == request.note_id`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.GetNote(context.Background(), tt.req)
+			var got []string
+			for _, v := range r.Violations() {
+				if v.Err.Error() != v.Name+": condition is false" {
+					t.Errorf("violation %s has error %v", v.Name, v.Err)
+				}
+				got = append(got, v.Name)
+			}
+			r.Reset()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionEval(t *testing.T) {
+	md := testpb.File_contracts_internal_testpb_notes_proto.Services().ByName("Notes").Methods().ByName("GetNote")
+	env, err := newEnv(md, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &testpb.GetNoteRequest{NoteId: 7, Token: "abc"}
+	resp := &testpb.Note{
+		NoteId: 7,
+		Kind:   testpb.Kind_KIND_LIST,
+		Tags:   []string{"home", "food"},
+		Author: &testpb.Author{UserId: 3, Name: "ada"},
+	}
+	tests := []struct {
+		src     string
+		resp    *testpb.Note
+		code    string
+		wantErr string
+	}{
+		{src: "response.note_id == request.note_id", resp: resp},
+		{src: `request.token.matches("^[a-c]+$")`},
+		{src: "response.kind == contracts.test.Kind.KIND_LIST", resp: resp},
+		{src: `size(response.tags) == 2 && response.author.name == "ada"`, resp: resp},
+		{src: "has(response.author)", resp: resp},
+		{src: "has(response.author)", resp: &testpb.Note{}, wantErr: "condition is false"},
+		// The fields of a missing response have their default values.
+		{src: "response.note_id == 0 && !has(response.author)", code: "NOT_FOUND"},
+		{src: `code != "OK" || response.note_id == 7`, code: "NOT_FOUND"},
+		{src: `calls["Authenticate"] == 0 && calls["contracts.test.Auth/Authenticate"] == 0`},
+		{src: "request.note_id / 0 == 0", wantErr: "division by zero"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			prg, err := compileCondition(env, tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = evalCondition(prg, map[string]any{
+				"request":  req,
+				"response": message(tt.resp, md.Output()),
+				"code":     tt.code,
+				"calls":    newCallCounts(contracts.RPCCallHistory{}),
+			})
+			if (err == nil) != (tt.wantErr == "") || err != nil && !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package contracts
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc/stats"
+)
+
+type statsHandler struct {
+	sc *ServerContract
+}
+
+// callAttempts counts the attempts made for a downstream call recorded by sc.
+type callAttempts struct {
+	sc *ServerContract
+	n  int32
+}
+
+// StatsHandler returns a client stats handler that counts the attempts made
+// for the RPC calls recorded by the UnaryClientInterceptor of sc, including
+// the retries made by gRPC. It must be installed on the client using
+// grpc.WithStatsHandler. Calls recorded by other ServerContracts are not
+// counted.
+func (sc *ServerContract) StatsHandler() stats.Handler {
+	return statsHandler{sc: sc}
+}
+
+func (statsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (h statsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	if b, ok := s.(*stats.Begin); ok && b.IsClient() {
+		if attempts, ok := ctx.Value(attemptsKey).(*callAttempts); ok && attempts.sc == h.sc {
+			atomic.AddInt32(&attempts.n, 1)
+		}
+	}
+}
+
+func (statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (statsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -0,0 +1,76 @@
+package contracts
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const retryServiceConfig = `{"methodConfig": [{
+	"name": [{"service": "test.Back"}],
+	"retryPolicy": {
+		"maxAttempts": 5,
+		"initialBackoff": "0.001s",
+		"maxBackoff": "0.001s",
+		"backoffMultiplier": 1,
+		"retryableStatusCodes": ["UNAVAILABLE"]
+	}
+}]}`
+
+func TestStatsHandlerAttempts(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		statsHandler bool
+		other        bool
+		want         int
+	}{
+		{name: "no retries", statsHandler: true, want: 1},
+		{name: "retries", failures: 2, statsHandler: true, want: 3},
+		{name: "retries without stats handler", failures: 2, want: 1},
+		{name: "stats handler of another ServerContract", failures: 2, statsHandler: true, other: true, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			sc, _ := newServerContract(t, []*ServiceContract{inspectHistory(func(calls RPCCallHistory) {
+				if lookup, err := calls.All().First(); err == nil {
+					attempts = lookup.Attempts
+				}
+			})})
+			var failures atomic.Int32
+			opts := []grpc.DialOption{grpc.WithDefaultServiceConfig(retryServiceConfig)}
+			if tt.statsHandler {
+				h := sc.StatsHandler()
+				if tt.other {
+					h = NewServerContract(t.Log).StatsHandler()
+				}
+				opts = append(opts, grpc.WithStatsHandler(h))
+			}
+			backCC := serveBack(t, sc, map[string]testservice.Handler{
+				"Lookup": func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+					if failures.Add(1) <= tt.failures {
+						return nil, status.Error(codes.Unavailable, "try again")
+					}
+					return in, nil
+				},
+			}, opts...)
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				_, err := call(ctx, backCC, lookupMethod, in.Value)
+				return in, err
+			})
+			if _, err := call(context.Background(), frontCC, getMethod, "note"); err != nil {
+				t.Fatal(err)
+			}
+			if attempts != tt.want {
+				t.Errorf("attempts = %d, want %d", attempts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package contracts
+
+import (
+	"fmt"
+	"regexp"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// StatusCondition is a condition on the status of an RPC that returned an error.
+type StatusCondition func(st *status.Status) error
+
+// MessageMatches returns a StatusCondition that requires the status message to
+// match the given regular expression. It panics if the expression cannot be parsed.
+func MessageMatches(expr string) StatusCondition {
+	re := regexp.MustCompile(expr)
+	return func(st *status.Status) error {
+		if !re.MatchString(st.Message()) {
+			return fmt.Errorf("status message %q does not match %q", st.Message(), expr)
+		}
+		return nil
+	}
+}
+
+// HasDetail returns a StatusCondition that requires the status to carry a
+// detail of the same type as msg, e.g., HasDetail(&errdetails.BadRequest{}).
+func HasDetail(msg proto.Message) StatusCondition {
+	name := msg.ProtoReflect().Descriptor().FullName()
+	return func(st *status.Status) error {
+		for _, detail := range st.Proto().GetDetails() {
+			if detail.MessageName() == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("status with code %s has no %s detail", st.Code(), name)
+	}
+}
+
+// checkStatus checks the error returned by an RPC against the status part of its contract.
+func (u *UnaryRPCContract) checkStatus(respErr error) []error {
+	st := status.Convert(respErr)
+	if st.Code() == codes.OK {
+		return nil
+	}
+	var errs []error
+	if u.AllowedCodes != nil && !containsCode(u.AllowedCodes, st.Code()) {
+		errs = append(errs, fmt.Errorf("status code %s is not allowed, allowed codes are %v", st.Code(), u.AllowedCodes))
+	}
+	for _, cond := range u.StatusConditions {
+		if err := cond(st); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func containsCode(allowed []codes.Code, code codes.Code) bool {
+	for _, c := range allowed {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func withDetail(code codes.Code, msg string) error {
+	st, err := status.New(code, msg).WithDetails(&errdetails.BadRequest{})
+	if err != nil {
+		panic(err)
+	}
+	return st.Err()
+}
+
+func TestCheckStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		contract *UnaryRPCContract
+		err      error
+		want     []string
+	}{
+		{
+			name:     "ok",
+			contract: &UnaryRPCContract{AllowedCodes: []codes.Code{}},
+		},
+		{
+			name:     "allowed code",
+			contract: &UnaryRPCContract{AllowedCodes: []codes.Code{codes.NotFound, codes.Unauthenticated}},
+			err:      status.Error(codes.NotFound, "note not found"),
+		},
+		{
+			name:     "disallowed code",
+			contract: &UnaryRPCContract{AllowedCodes: []codes.Code{codes.NotFound}},
+			err:      status.Error(codes.Internal, "oops"),
+			want:     []string{"status code Internal is not allowed, allowed codes are [NotFound]"},
+		},
+		{
+			name:     "no code allowed",
+			contract: &UnaryRPCContract{AllowedCodes: []codes.Code{}},
+			err:      status.Error(codes.NotFound, "note not found"),
+			want:     []string{"status code NotFound is not allowed"},
+		},
+		{
+			name:     "any code allowed",
+			contract: &UnaryRPCContract{},
+			err:      status.Error(codes.Internal, "oops"),
+		},
+		{
+			name:     "non-status error",
+			contract: &UnaryRPCContract{AllowedCodes: []codes.Code{codes.NotFound}},
+			err:      errors.New("oops"),
+			want:     []string{"status code Unknown is not allowed"},
+		},
+		{
+			name:     "message matches",
+			contract: &UnaryRPCContract{StatusConditions: []StatusCondition{MessageMatches(`^note \d+ not found$`)}},
+			err:      status.Error(codes.NotFound, "note 12 not found"),
+		},
+		{
+			name:     "message does not match",
+			contract: &UnaryRPCContract{StatusConditions: []StatusCondition{MessageMatches(`^note \d+ not found$`)}},
+			err:      status.Error(codes.NotFound, "Not Found"),
+			want:     []string{`status message "Not Found" does not match "^note \\d+ not found$"`},
+		},
+		{
+			name:     "has detail",
+			contract: &UnaryRPCContract{StatusConditions: []StatusCondition{HasDetail(&errdetails.BadRequest{})}},
+			err:      withDetail(codes.InvalidArgument, "bad note"),
+		},
+		{
+			name:     "missing detail",
+			contract: &UnaryRPCContract{StatusConditions: []StatusCondition{HasDetail(&errdetails.ErrorInfo{})}},
+			err:      withDetail(codes.InvalidArgument, "bad note"),
+			want:     []string{"status with code InvalidArgument has no google.rpc.ErrorInfo detail"},
+		},
+		{
+			name: "all violations",
+			contract: &UnaryRPCContract{
+				AllowedCodes:     []codes.Code{codes.NotFound},
+				StatusConditions: []StatusCondition{MessageMatches("^note"), HasDetail(&errdetails.BadRequest{})},
+			},
+			err:  status.Error(codes.Internal, "oops"),
+			want: []string{"is not allowed", "does not match", "has no google.rpc.BadRequest detail"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.contract.checkStatus(tt.err)
+			if len(errs) != len(tt.want) {
+				t.Fatalf("got errors %v, want %d errors", errs, len(tt.want))
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), tt.want[i]) {
+					t.Errorf("got error %q, want it to contain %q", err, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStatusContract(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		want     []string
+		wantCode codes.Code
+	}{
+		{name: "success", wantCode: codes.OK},
+		{name: "allowed", err: status.Error(codes.NotFound, "note 1 not found"), wantCode: codes.NotFound},
+		{
+			name: "disallowed",
+			err:  status.Error(codes.Internal, "oops"),
+			want: []string{
+				"status code Internal is not allowed, allowed codes are [NotFound]",
+				`status message "oops" does not match "^note \\d+ not found$"`,
+			},
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, v := newServerContract(t, []*ServiceContract{{
+				ServiceName: frontService,
+				RPCContracts: []*UnaryRPCContract{{
+					MethodName:       "Get",
+					AllowedCodes:     []codes.Code{codes.NotFound},
+					StatusConditions: []StatusCondition{MessageMatches(`^note \d+ not found$`)},
+				}},
+			}})
+			frontCC := serveFront(t, sc, func(ctx context.Context, in *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+				return in, tt.err
+			})
+			_, err := call(context.Background(), frontCC, getMethod, "note")
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("got code %s, want %s", got, tt.wantCode)
+			}
+			if got := v.errors(); !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilStatusCondition(t *testing.T) {
+	sc := NewServerContract(t.Log)
+	err := sc.RegisterServiceContract(&ServiceContract{
+		ServiceName:  frontService,
+		RPCContracts: []*UnaryRPCContract{{MethodName: "Get", StatusConditions: []StatusCondition{nil}}},
+	})
+	if err == nil {
+		t.Error("RegisterServiceContract accepted a nil status condition")
+	}
+}
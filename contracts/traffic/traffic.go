@@ -0,0 +1,176 @@
+// Package traffic records the requests served by a ServerContract, together
+// with their downstream calls, and replays them offline against contracts.
+//
+// A Recorder is a contracts.Collector that writes every reported request to a
+// traffic file:
+//
+//	f, err := os.Create("traffic.jsonl")
+//	rec := traffic.NewRecorder(f, traffic.JSONL)
+//	serverContract := contracts.NewServerContract(log.Println, contracts.WithCollector(rec))
+//
+// Replay checks the recorded requests against a new version of the contracts
+// without any live services, to find the past requests that would violate it:
+//
+//	f, err := os.Open("traffic.jsonl")
+//	results, err := traffic.Replay(traffic.NewReader(f, traffic.JSONL), newServerContract)
+//
+// Each record is a contracts.collector.ReportRequest message, either encoded
+// in the protobuf JSON format, one record per line, or in the protobuf wire
+// format, each record prefixed by its length.
+package traffic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/collector"
+	pb "github.com/shayanh/grpc-go-contracts/contracts/collector/collectorpb"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Format is the encoding of a traffic file.
+type Format int
+
+const (
+	// JSONL encodes each record in the protobuf JSON format on a separate line.
+	JSONL Format = iota
+	// Delimited encodes each record in the protobuf wire format, prefixed by
+	// its length as a varint.
+	Delimited
+)
+
+// Recorder is a contracts.Collector that writes the reported requests to a
+// traffic file.
+type Recorder struct {
+	format Format
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRecorder creates a Recorder that writes to w in the given format.
+func NewRecorder(w io.Writer, format Format) *Recorder {
+	return &Recorder{w: w, format: format}
+}
+
+// Report writes the report to the traffic file.
+func (r *Recorder) Report(ctx context.Context, report *contracts.CallReport) error {
+	in := collector.ReportToProto(report)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.format {
+	case JSONL:
+		data, err := protojson.Marshal(in)
+		if err != nil {
+			return err
+		}
+		_, err = r.w.Write(append(data, '\n'))
+		return err
+	case Delimited:
+		_, err := protodelim.MarshalTo(r.w, in)
+		return err
+	}
+	return fmt.Errorf("traffic: unknown format %d", r.format)
+}
+
+// Reader reads the records of a traffic file.
+type Reader struct {
+	r      *bufio.Reader
+	format Format
+	record int
+}
+
+// NewReader creates a Reader that reads from r in the given format.
+func NewReader(r io.Reader, format Format) *Reader {
+	return &Reader{r: bufio.NewReader(r), format: format}
+}
+
+// Next returns the next record. It returns io.EOF if there are no more records.
+func (r *Reader) Next() (*contracts.CallReport, error) {
+	in := new(pb.ReportRequest)
+	switch r.format {
+	case JSONL:
+		var line []byte
+		for len(line) == 0 {
+			var err error
+			line, err = r.r.ReadBytes('\n')
+			if err == io.EOF && len(bytes.TrimSpace(line)) > 0 {
+				err = nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			line = bytes.TrimSpace(line)
+		}
+		r.record++
+		if err := protojson.Unmarshal(line, in); err != nil {
+			return nil, fmt.Errorf("traffic: record %d: %v", r.record, err)
+		}
+	case Delimited:
+		if err := protodelim.UnmarshalFrom(r.r, in); err != nil {
+			if err == io.EOF {
+				return nil, err
+			}
+			return nil, fmt.Errorf("traffic: record %d: %v", r.record+1, err)
+		}
+		r.record++
+	default:
+		return nil, fmt.Errorf("traffic: unknown format %d", r.format)
+	}
+	if in.Call == nil {
+		return nil, fmt.Errorf("traffic: record %d has no call", r.record)
+	}
+	return collector.ReportFromProto(in), nil
+}
+
+// Result is the result of replaying a recorded request.
+type Result struct {
+	Report     *contracts.CallReport
+	Violations []*contracts.Violation
+}
+
+// Replay replays all of the records of the traffic file against the server
+// contract and returns the results of the requests, in the order of the
+// records. See contracts.ServerContract.Replay. The server contract must not
+// serve requests meanwhile, otherwise their violations are attributed to the
+// replayed requests.
+func Replay(r *Reader, sc *contracts.ServerContract) ([]*Result, error) {
+	var mu sync.Mutex
+	var current *Result
+	remove := sc.AddReporter(contracts.ReporterFunc(func(v *contracts.Violation) {
+		mu.Lock()
+		defer mu.Unlock()
+		if current != nil {
+			current.Violations = append(current.Violations, v)
+		}
+	}))
+	defer remove()
+
+	var results []*Result
+	for {
+		report, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return results, nil
+		}
+		if err != nil {
+			return results, err
+		}
+		res := &Result{Report: report}
+		mu.Lock()
+		current = res
+		mu.Unlock()
+		sc.Replay(report)
+		mu.Lock()
+		current = nil
+		mu.Unlock()
+		results = append(results, res)
+	}
+}
@@ -0,0 +1,169 @@
+package traffic
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/contracttest"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+type notes struct {
+	testpb.UnimplementedNotesServer
+	auth testpb.AuthClient
+}
+
+func (s *notes) GetNote(ctx context.Context, in *testpb.GetNoteRequest) (*testpb.Note, error) {
+	if in.Token != "" {
+		if _, err := s.auth.Authenticate(ctx, &testpb.AuthenticateRequest{Token: in.Token}); err != nil {
+			return nil, err
+		}
+	}
+	return &testpb.Note{NoteId: in.NoteId}, nil
+}
+
+type auth struct {
+	testpb.UnimplementedAuthServer
+}
+
+func (auth) Authenticate(ctx context.Context, in *testpb.AuthenticateRequest) (*testpb.AuthenticateResponse, error) {
+	return &testpb.AuthenticateResponse{UserId: 1}, nil
+}
+
+// record serves the requests and returns the recorded traffic.
+func record(t *testing.T, format Format, reqs ...*testpb.GetNoteRequest) []byte {
+	var buf bytes.Buffer
+	sc := contracts.NewServerContract(t.Log, contracts.WithCollector(NewRecorder(&buf, format)))
+	s := &notes{}
+	conn := contracttest.Serve(t, sc, func(gs *grpc.Server) {
+		testpb.RegisterNotesServer(gs, s)
+		testpb.RegisterAuthServer(gs, auth{})
+	})
+	s.auth = testpb.NewAuthClient(conn)
+	client := testpb.NewNotesClient(conn)
+	for _, req := range reqs {
+		if _, err := client.GetNote(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	reqs := []*testpb.GetNoteRequest{{NoteId: 1, Token: "t"}, {NoteId: 2}, {NoteId: 3, Token: "t"}}
+	for _, format := range []Format{JSONL, Delimited} {
+		data := record(t, format, reqs...)
+
+		sc := contracts.NewServerContract(t.Log)
+		err := sc.RegisterServiceContract(&contracts.ServiceContract{
+			ServiceName: "contracts.test.Notes",
+			RPCContracts: []*contracts.UnaryRPCContract{{
+				MethodName: "GetNote",
+				PostConditions: []contracts.Condition{func(resp *testpb.Note, respErr error, req *testpb.GetNoteRequest, calls contracts.RPCCallHistory) error {
+					if calls.Filter("contracts.test.Auth", "Authenticate").Empty() {
+						return errors.New("caller is not authenticated")
+					}
+					return nil
+				}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := Replay(NewReader(bytes.NewReader(data), format), sc)
+		if err != nil {
+			t.Fatalf("format %d: %v", format, err)
+		}
+
+		// Authenticate is served by the same server, so it is recorded too.
+		var got []string
+		var replayed []*testpb.GetNoteRequest
+		for _, res := range results {
+			s := res.Report.Call.FullMethod
+			if req, ok := res.Report.Call.Request.(*testpb.GetNoteRequest); ok {
+				replayed = append(replayed, req)
+			}
+			for _, v := range res.Violations {
+				s += ": " + v.Err.Error()
+			}
+			got = append(got, s)
+		}
+		want := []string{
+			"/contracts.test.Auth/Authenticate",
+			"/contracts.test.Notes/GetNote",
+			"/contracts.test.Notes/GetNote: caller is not authenticated",
+			"/contracts.test.Auth/Authenticate",
+			"/contracts.test.Notes/GetNote",
+		}
+		if !slices.EqualFunc(replayed, reqs, func(a, b *testpb.GetNoteRequest) bool { return proto.Equal(a, b) }) {
+			t.Errorf("format %d: replayed requests %v, want %v", format, replayed, reqs)
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("format %d: results:\n%s\nwant:\n%s", format, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestReader(t *testing.T) {
+	jsonl := record(t, JSONL, &testpb.GetNoteRequest{NoteId: 1})
+	delimited := record(t, Delimited, &testpb.GetNoteRequest{NoteId: 1})
+	tests := []struct {
+		name    string
+		format  Format
+		data    []byte
+		records int
+		wantErr string
+	}{
+		{name: "jsonl", format: JSONL, data: jsonl, records: 1},
+		{name: "blank lines", format: JSONL, data: append(append([]byte("\n\n"), jsonl...), "\n  \n"...), records: 1},
+		{name: "no final newline", format: JSONL, data: bytes.TrimSpace(jsonl), records: 1},
+		{name: "invalid record", format: JSONL, data: append(jsonl, "{\n"...), records: 1, wantErr: "traffic: record 2:"},
+		{name: "no call", format: JSONL, data: []byte(`{"traceId": "t"}`), wantErr: "traffic: record 1 has no call"},
+		{name: "delimited", format: Delimited, data: delimited, records: 1},
+		{name: "truncated", format: Delimited, data: delimited[:len(delimited)-1], wantErr: "traffic: record 1:"},
+		{name: "unknown format", format: Format(9), data: jsonl, wantErr: "traffic: unknown format 9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReader(bytes.NewReader(tt.data), tt.format)
+			var records int
+			var err error
+			for {
+				var report *contracts.CallReport
+				if report, err = r.Next(); err != nil {
+					break
+				}
+				if report.Call.FullMethod != "/contracts.test.Notes/GetNote" {
+					t.Errorf("read call to %s", report.Call.FullMethod)
+				}
+				records++
+			}
+			if records != tt.records {
+				t.Errorf("read %d records, want %d", records, tt.records)
+			}
+			if tt.wantErr == "" {
+				if err != io.EOF {
+					t.Errorf("Next() = %v, want io.EOF", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Next() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecorderUnknownFormat(t *testing.T) {
+	r := NewRecorder(io.Discard, Format(9))
+	err := r.Report(context.Background(), &contracts.CallReport{Call: &contracts.UnaryRPCCall{FullMethod: "/contracts.test.Notes/GetNote"}})
+	if err == nil {
+		t.Error("Report() returned no error for an unknown format")
+	}
+}
@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: contracts/validate/internal/testpb/test.proto
+
+package testpb
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        int32                  `protobuf:"varint,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNoteRequest) Reset() {
+	*x = GetNoteRequest{}
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteRequest) ProtoMessage() {}
+
+func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+	return file_contracts_validate_internal_testpb_test_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetNoteRequest) GetNoteId() int32 {
+	if x != nil {
+		return x.NoteId
+	}
+	return 0
+}
+
+func (x *GetNoteRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type Note struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Ttl           *durationpb.Duration   `protobuf:"bytes,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Note) Reset() {
+	*x = Note{}
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Note) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Note) ProtoMessage() {}
+
+func (x *Note) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Note.ProtoReflect.Descriptor instead.
+func (*Note) Descriptor() ([]byte, []int) {
+	return file_contracts_validate_internal_testpb_test_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Note) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Note) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Note) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Note) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// InvalidRule declares a rule that does not compile.
+type InvalidRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidRule) Reset() {
+	*x = InvalidRule{}
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidRule) ProtoMessage() {}
+
+func (x *InvalidRule) ProtoReflect() protoreflect.Message {
+	mi := &file_contracts_validate_internal_testpb_test_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidRule.ProtoReflect.Descriptor instead.
+func (*InvalidRule) Descriptor() ([]byte, []int) {
+	return file_contracts_validate_internal_testpb_test_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *InvalidRule) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_contracts_validate_internal_testpb_test_proto protoreflect.FileDescriptor
+
+const file_contracts_validate_internal_testpb_test_proto_rawDesc = "" +
+	"\n" +
+	"-contracts/validate/internal/testpb/test.proto\x12\x17contracts.validate.test\x1a\x1bbuf/validate/validate.proto\x1a\x1egoogle/protobuf/duration.proto\"Q\n" +
+	"\x0eGetNoteRequest\x12 \n" +
+	"\anote_id\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x06noteId\x12\x1d\n" +
+	"\x05token\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\x05token\"\xdf\x01\n" +
+	"\x04Note\x12\x17\n" +
+	"\x02id\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x127\n" +
+	"\x03ttl\x18\x04 \x01(\v2\x19.google.protobuf.DurationB\n" +
+	"\xbaH\a\xaa\x01\x042\x02\b\x01R\x03ttl:[\xbaHX\x1aV\n" +
+	"\x12note.title_in_text\x12\x1etext must start with the title\x1a this.text.startsWith(this.title)\"D\n" +
+	"\vInvalidRule\x125\n" +
+	"\x02id\x18\x01 \x01(\x05B%\xbaH\"\xba\x01\x1f\n" +
+	"\finvalid_rule\x1a\x0fthis.size() > 0R\x02id2\\\n" +
+	"\x05Notes\x12S\n" +
+	"\aGetNote\x12'.contracts.validate.test.GetNoteRequest\x1a\x1d.contracts.validate.test.Note\"\x00BIZGgithub.com/shayanh/grpc-go-contracts/contracts/validate/internal/testpbb\x06proto3"
+
+var (
+	file_contracts_validate_internal_testpb_test_proto_rawDescOnce sync.Once
+	file_contracts_validate_internal_testpb_test_proto_rawDescData []byte
+)
+
+func file_contracts_validate_internal_testpb_test_proto_rawDescGZIP() []byte {
+	file_contracts_validate_internal_testpb_test_proto_rawDescOnce.Do(func() {
+		file_contracts_validate_internal_testpb_test_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_contracts_validate_internal_testpb_test_proto_rawDesc), len(file_contracts_validate_internal_testpb_test_proto_rawDesc)))
+	})
+	return file_contracts_validate_internal_testpb_test_proto_rawDescData
+}
+
+var file_contracts_validate_internal_testpb_test_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_contracts_validate_internal_testpb_test_proto_goTypes = []any{
+	(*GetNoteRequest)(nil),      // 0: contracts.validate.test.GetNoteRequest
+	(*Note)(nil),                // 1: contracts.validate.test.Note
+	(*InvalidRule)(nil),         // 2: contracts.validate.test.InvalidRule
+	(*durationpb.Duration)(nil), // 3: google.protobuf.Duration
+}
+var file_contracts_validate_internal_testpb_test_proto_depIdxs = []int32{
+	3, // 0: contracts.validate.test.Note.ttl:type_name -> google.protobuf.Duration
+	0, // 1: contracts.validate.test.Notes.GetNote:input_type -> contracts.validate.test.GetNoteRequest
+	1, // 2: contracts.validate.test.Notes.GetNote:output_type -> contracts.validate.test.Note
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_contracts_validate_internal_testpb_test_proto_init() }
+func file_contracts_validate_internal_testpb_test_proto_init() {
+	if File_contracts_validate_internal_testpb_test_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contracts_validate_internal_testpb_test_proto_rawDesc), len(file_contracts_validate_internal_testpb_test_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_contracts_validate_internal_testpb_test_proto_goTypes,
+		DependencyIndexes: file_contracts_validate_internal_testpb_test_proto_depIdxs,
+		MessageInfos:      file_contracts_validate_internal_testpb_test_proto_msgTypes,
+	}.Build()
+	File_contracts_validate_internal_testpb_test_proto = out.File
+	file_contracts_validate_internal_testpb_test_proto_goTypes = nil
+	file_contracts_validate_internal_testpb_test_proto_depIdxs = nil
+}
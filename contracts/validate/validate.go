@@ -0,0 +1,68 @@
+// Package validate implements a contracts.Validator that checks messages
+// against the standard buf.validate (protovalidate) rules declared in their
+// proto annotations, e.g.:
+//
+//	message GetNoteRequest {
+//	    int32 note_id = 1 [(buf.validate.field).int32.gte = 0];
+//	    string token = 2 [(buf.validate.field).string.min_len = 1];
+//	}
+//
+// Use it with contracts.WithValidator. Request rules are then checked as
+// preconditions and response rules as postconditions.
+//
+// The rules are evaluated by protovalidate-go, so all of the rules are
+// supported, including custom CEL expressions and the rules of well-known
+// types, e.g., google.protobuf.Duration.
+package validate
+
+import (
+	"errors"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/proto"
+)
+
+// Error is returned when a message violates its rules.
+type Error struct {
+	// Violations describe the violated rules in the form of "field.path: reason".
+	Violations []string
+}
+
+func (e *Error) Error() string {
+	return "validation failed: " + strings.Join(e.Violations, "; ")
+}
+
+// Validator checks messages against their buf.validate rules.
+type Validator struct {
+	v protovalidate.Validator
+}
+
+// New creates a Validator. The options are passed to protovalidate.New, e.g.,
+// protovalidate.WithMessages to compile the rules of the given messages
+// upfront. Invalid rules are reported when a message is validated.
+func New(opts ...protovalidate.ValidatorOption) (*Validator, error) {
+	v, err := protovalidate.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{v: v}, nil
+}
+
+// Validate returns an *Error if the message violates its rules. Other errors
+// are returned if the rules of the message are invalid or cannot be evaluated.
+func (v *Validator) Validate(msg proto.Message) error {
+	err := v.v.Validate(msg)
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+	violations := make([]string, len(verr.Violations))
+	for i, violation := range verr.Violations {
+		violations[i] = violation.Proto.GetMessage()
+		if path := protovalidate.FieldPathString(violation.Proto.GetField()); path != "" {
+			violations[i] = path + ": " + violations[i]
+		}
+	}
+	return &Error{Violations: violations}
+}
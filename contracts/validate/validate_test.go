@@ -0,0 +1,163 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"github.com/shayanh/grpc-go-contracts/contracts"
+	"github.com/shayanh/grpc-go-contracts/contracts/internal/testservice"
+	"github.com/shayanh/grpc-go-contracts/contracts/validate/internal/testpb"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want []string
+	}{
+		{
+			name: "valid request",
+			msg:  &testpb.GetNoteRequest{NoteId: 0, Token: "t"},
+		},
+		{
+			name: "invalid request",
+			msg:  &testpb.GetNoteRequest{NoteId: -1},
+			want: []string{
+				"note_id: value must be greater than or equal to 0",
+				"token: value length must be at least 1 characters",
+			},
+		},
+		{
+			name: "valid response",
+			msg:  &testpb.Note{Id: 1, Title: "todo", Text: "todo: test", Ttl: durationpb.New(60e9)},
+		},
+		{
+			name: "unset well-known type",
+			msg:  &testpb.Note{Id: 1},
+		},
+		{
+			name: "invalid well-known type",
+			msg:  &testpb.Note{Id: 1, Ttl: durationpb.New(1e6)},
+			want: []string{"ttl: value must be greater than or equal to 1s"},
+		},
+		{
+			name: "message cel rule",
+			msg:  &testpb.Note{Id: 1, Title: "todo", Text: "test"},
+			want: []string{"text must start with the title"},
+		},
+	}
+	v, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.msg)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var verr *Error
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() = %v, want *Error", err)
+			}
+			if !slices.Equal(verr.Violations, tt.want) {
+				t.Errorf("violations = %q, want %q", verr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []protovalidate.ValidatorOption
+	}{
+		{name: "lazy"},
+		{name: "upfront", opts: []protovalidate.ValidatorOption{protovalidate.WithMessages(&testpb.InvalidRule{})}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := New(tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = v.Validate(&testpb.InvalidRule{Id: 1})
+			var verr *Error
+			if err == nil || errors.As(err, &verr) {
+				t.Errorf("Validate() = %v, want a compilation error", err)
+			}
+		})
+	}
+}
+
+type notes struct {
+	testpb.UnimplementedNotesServer
+	note *testpb.Note
+}
+
+func (s *notes) GetNote(ctx context.Context, in *testpb.GetNoteRequest) (*testpb.Note, error) {
+	return s.note, nil
+}
+
+func TestServerContract(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *testpb.GetNoteRequest
+		note *testpb.Note
+		want []string
+	}{
+		{
+			name: "valid",
+			req:  &testpb.GetNoteRequest{Token: "t"},
+			note: &testpb.Note{Id: 1},
+		},
+		{
+			name: "invalid request",
+			req:  &testpb.GetNoteRequest{NoteId: -1, Token: "t"},
+			note: &testpb.Note{Id: 1},
+			want: []string{"validation failed: note_id: value must be greater than or equal to 0"},
+		},
+		{
+			name: "invalid response",
+			req:  &testpb.GetNoteRequest{Token: "t"},
+			note: &testpb.Note{Id: 1, Text: "text", Title: "title"},
+			want: []string{"validation failed: text must start with the title"},
+		},
+	}
+	v, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got []string
+			sc := contracts.NewServerContract(func(args ...interface{}) {
+				t.Log(args...)
+				mu.Lock()
+				defer mu.Unlock()
+				got = append(got, args[0].(error).Error())
+			}, contracts.WithValidator(v))
+			cc := testservice.Serve(t, &testpb.Notes_ServiceDesc, &notes{note: tt.note},
+				[]grpc.ServerOption{grpc.UnaryInterceptor(sc.UnaryServerInterceptor())})
+			if _, err := testpb.NewNotesClient(cc).GetNote(context.Background(), tt.req); err != nil {
+				t.Fatal(err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package contracts
+
+import (
+	"errors"
+	"sync"
+)
+
+// Names of the violations of unnamed conditions and built-in checks.
+const (
+	preConditionViolation   = "precondition"
+	postConditionViolation  = "postcondition"
+	validationViolation     = "validation"
+	statusViolation         = "status"
+	latencyViolation        = "latency"
+	idempotencyViolation    = "idempotency"
+	callSequenceViolation   = "call-sequence"
+	metadataViolation       = "metadata"
+	deadlineViolation       = "deadline"
+	requestContextViolation = "request-context"
+)
+
+// Violation is a contract violation detected by a ServerContract or a
+// ClientContract.
+type Violation struct {
+	// Name identifies the violated condition. It is the name of the condition
+	// for named conditions, see Named. Otherwise, it is "precondition" or
+	// "postcondition" for conditions, and "validation", "status", "latency",
+	// "idempotency", "call-sequence", "metadata", "deadline" or
+	// "request-context" for the respective built-in checks.
+	Name string
+	// FullMethod is the served RPC, i.e., /package.service/method. It is empty
+	// if the violation cannot be attributed to a served RPC, e.g., it is
+	// detected by a ClientContract.
+	FullMethod string
+	// Call is the called RPC if the violation concerns a downstream call, or
+	// a call checked by a ClientContract.
+	Call string
+	// Err describes the violation.
+	Err error
+	// Request is the request of the served RPC, or of the called RPC if Call is set.
+	Request interface{}
+	// Response is the response of the served RPC, or of the RPC called by a
+	// ClientContract. It is nil if the violation was detected before the
+	// handler returned or the call was made.
+	Response interface{}
+	// ResponseError is the error returned by the handler of the served RPC,
+	// or by the RPC called by a ClientContract.
+	ResponseError error
+
+	responded bool
+}
+
+func (v *Violation) Error() string {
+	method := v.FullMethod
+	if method == "" {
+		method = v.Call
+	}
+	return method + ": " + v.Err.Error()
+}
+
+// Unwrap returns the error describing the violation.
+func (v *Violation) Unwrap() error {
+	return v.Err
+}
+
+// resolveName names the violation after the violated condition if the
+// condition is named.
+func (v *Violation) resolveName() {
+	var condErr *ConditionError
+	if errors.As(v.Err, &condErr) {
+		v.Name = condErr.Name
+	}
+}
+
+func (v *Violation) logArgs() []interface{} {
+	args := []interface{}{v.Err}
+	if v.FullMethod != "" {
+		args = append(args, v.FullMethod)
+	}
+	if v.Call != "" {
+		args = append(args, v.Call)
+	}
+	args = append(args, v.Request)
+	if v.responded {
+		args = append(args, v.Response, v.ResponseError)
+	}
+	return args
+}
+
+// Reporter is notified of the contract violations detected by a
+// ServerContract or a ClientContract, in addition to its logger function.
+// Report may be called concurrently.
+type Reporter interface {
+	Report(v *Violation)
+}
+
+// ReporterFunc is an adapter to use an ordinary function as a Reporter.
+type ReporterFunc func(v *Violation)
+
+// Report calls f(v).
+func (f ReporterFunc) Report(v *Violation) {
+	f(v)
+}
+
+type reporters struct {
+	mu   sync.RWMutex
+	next int
+	m    map[int]Reporter
+}
+
+// add adds a reporter and returns a function that removes it.
+func (rs *reporters) add(r Reporter) (remove func()) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.m == nil {
+		rs.m = make(map[int]Reporter)
+	}
+	id := rs.next
+	rs.next++
+	rs.m[id] = r
+	return func() {
+		rs.mu.Lock()
+		defer rs.mu.Unlock()
+		delete(rs.m, id)
+	}
+}
+
+// report logs a violation and notifies the reporters of it.
+func (rs *reporters) report(logFunc LogFunc, v *Violation) {
+	v.resolveName()
+	logFunc(v.logArgs()...)
+
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+	for _, r := range rs.m {
+		r.Report(v)
+	}
+}
+
+// AddReporter adds a reporter to the server contract. It returns a function
+// that removes the reporter.
+func (sc *ServerContract) AddReporter(r Reporter) (remove func()) {
+	return sc.reporters.add(r)
+}
+
+// report logs a violation and notifies the reporters of sc. It returns false
+// if the violation is dropped because its service or condition is disabled,
+// or violations are ignored.
+func (sc *ServerContract) report(v *Violation) bool {
+	v.resolveName()
+	if sc.ViolationPolicy() == IgnoreViolations || !sc.ConditionEnabled(v.FullMethod, v.Name) {
+		return false
+	}
+	if v.FullMethod != "" && !sc.ServiceEnabled(serviceName(v.FullMethod)) {
+		return false
+	}
+	sc.reporters.report(sc.logFunc, v)
+	return true
+}
+
+// reportCall reports a violation concerning a downstream call.
+func (sc *ServerContract) reportCall(name string, err error, fullMethod, method string, req interface{}) {
+	sc.report(&Violation{Name: name, FullMethod: fullMethod, Call: method, Err: err, Request: req})
+}